@@ -0,0 +1,89 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"encoding/binary"
+
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+const (
+	// TargetAggregatorsPerCommittee is the target number of aggregators in each attestation committee.
+	TargetAggregatorsPerCommittee = 16
+	// SyncCommitteeSubnetCount is the number of sync committee subnets.
+	SyncCommitteeSubnetCount = 4
+	// TargetAggregatorsPerSyncSubcommittee is the target number of aggregators in each sync subcommittee.
+	TargetAggregatorsPerSyncSubcommittee = 16
+)
+
+// IsAggregator returns true if the holder of the selection proof is an aggregator for its attestation committee.
+// Follows is_aggregator in the consensus specification.
+func IsAggregator(committeeSize uint64, selectionProof []byte) bool {
+	modulo := committeeSize / TargetAggregatorsPerCommittee
+	if modulo < 1 {
+		modulo = 1
+	}
+
+	return isSelected(selectionProof, modulo)
+}
+
+// IsSyncCommitteeAggregator returns true if the holder of the selection proof is an aggregator for its sync subcommittee.
+// Follows is_sync_committee_aggregator in the consensus specification.
+func IsSyncCommitteeAggregator(selectionProof []byte) bool {
+	modulo := uint64(SyncCommitteeSize / SyncCommitteeSubnetCount / TargetAggregatorsPerSyncSubcommittee)
+	if modulo < 1 {
+		modulo = 1
+	}
+
+	return isSelected(selectionProof, modulo)
+}
+
+// SlotSelectionProof generates the selection proof used to decide if a validator is an attestation aggregator for a slot.
+// Follows get_slot_signature in the consensus specification.
+func SlotSelectionProof(key *e2types.BLSPrivateKey,
+	slot uint64,
+	forkVersion []byte,
+	genesisValidatorsRoot []byte,
+) (
+	e2types.Signature,
+	error,
+) {
+	return signRoot(key, uint64Root(slot), e2types.DomainSelectionProof, forkVersion, genesisValidatorsRoot)
+}
+
+// SyncCommitteeSelectionProof generates the selection proof used to decide if a validator is a sync committee aggregator
+// for a slot and subcommittee.
+// Follows get_sync_committee_selection_proof in the consensus specification.
+func SyncCommitteeSelectionProof(key *e2types.BLSPrivateKey,
+	slot uint64,
+	subcommitteeIndex uint64,
+	forkVersion []byte,
+	genesisValidatorsRoot []byte,
+) (
+	e2types.Signature,
+	error,
+) {
+	// The sync aggregator selection data container has two uint64 fields.
+	objectRoot := SHA256(uint64Root(slot), uint64Root(subcommitteeIndex))
+
+	return signRoot(key, objectRoot, e2types.DomainSyncCommitteeSelectionProof, forkVersion, genesisValidatorsRoot)
+}
+
+// isSelected returns true if the hash of the selection proof is a multiple of the modulo.
+func isSelected(selectionProof []byte, modulo uint64) bool {
+	return binary.LittleEndian.Uint64(SHA256(selectionProof)[:8])%modulo == 0
+}
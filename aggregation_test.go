@@ -0,0 +1,110 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestIsAggregator(t *testing.T) {
+	tests := []struct {
+		name           string
+		committeeSize  uint64
+		selectionProof []byte
+		res            bool
+	}{
+		{
+			name:           "SmallCommittee",
+			committeeSize:  10,
+			selectionProof: bytes.Repeat([]byte{0x00}, 96),
+			res:            true,
+		},
+		{
+			name:           "Selected",
+			committeeSize:  64,
+			selectionProof: bytes.Repeat([]byte{0x16}, 96),
+			res:            true,
+		},
+		{
+			name:           "NotSelected",
+			committeeSize:  64,
+			selectionProof: bytes.Repeat([]byte{0x00}, 96),
+			res:            false,
+		},
+		{
+			name:           "LargeCommitteeSelected",
+			committeeSize:  256,
+			selectionProof: bytes.Repeat([]byte{0x02}, 96),
+			res:            true,
+		},
+		{
+			name:           "LargeCommitteeNotSelected",
+			committeeSize:  256,
+			selectionProof: bytes.Repeat([]byte{0x0c}, 96),
+			res:            false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.res, util.IsAggregator(test.committeeSize, test.selectionProof))
+		})
+	}
+}
+
+func TestIsSyncCommitteeAggregator(t *testing.T) {
+	assert.True(t, util.IsSyncCommitteeAggregator(bytes.Repeat([]byte{0x0c}, 96)))
+	assert.False(t, util.IsSyncCommitteeAggregator(bytes.Repeat([]byte{0x16}, 96)))
+}
+
+func TestSelectionProofs(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	key, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	forkVersion := _byteArray("04000000")
+	genesisValidatorsRoot := _byteArray("4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95")
+
+	_, err = util.SlotSelectionProof(nil, 1, forkVersion, genesisValidatorsRoot)
+	require.EqualError(t, err, "no key supplied")
+	_, err = util.SlotSelectionProof(key, 1, forkVersion[:3], genesisValidatorsRoot)
+	require.EqualError(t, err, "failed to compute domain: fork version must be 4 bytes in length")
+
+	slotRoot := make([]byte, 32)
+	slotRoot[0] = 0x0a
+	domain, err := e2types.ComputeDomain(e2types.DomainSelectionProof, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	signingRoot, err := util.ComputeSigningRoot(slotRoot, domain)
+	require.NoError(t, err)
+	proof, err := util.SlotSelectionProof(key, 10, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	assert.True(t, proof.Verify(signingRoot, key.PublicKey()))
+
+	indexRoot := make([]byte, 32)
+	indexRoot[0] = 0x02
+	domain, err = e2types.ComputeDomain(e2types.DomainSyncCommitteeSelectionProof, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	signingRoot, err = util.ComputeSigningRoot(util.SHA256(slotRoot, indexRoot), domain)
+	require.NoError(t, err)
+	proof, err = util.SyncCommitteeSelectionProof(key, 10, 2, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	assert.True(t, proof.Verify(signingRoot, key.PublicKey()))
+}
@@ -0,0 +1,171 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Gwei is an amount of Ether in Gwei, as used by the consensus layer.
+type Gwei uint64
+
+// Wei is an amount of Ether in wei, as used by the execution layer.
+type Wei big.Int
+
+var weiPerGwei = big.NewInt(1_000_000_000)
+
+// ParseGwei parses an amount of Ether in to Gwei.
+// The amount is a decimal number with an optional unit of "ETH" or "Ether", "Gwei" or "wei", for example "32",
+// "0.5 ETH" or "1000000000 gwei".  Amounts without a unit are in Ether.
+// An error is returned if the amount is not a whole number of Gwei, or is too large to be held.
+func ParseGwei(input string) (Gwei, error) {
+	wei, err := parseWei(input)
+	if err != nil {
+		return 0, err
+	}
+	res, err := (*Wei)(wei).Gwei()
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid amount %q", input)
+	}
+
+	return res, nil
+}
+
+// Wei returns the amount in wei.
+func (g Gwei) Wei() *Wei {
+	res := new(big.Int).SetUint64(uint64(g))
+
+	return (*Wei)(res.Mul(res, weiPerGwei))
+}
+
+// String returns the amount in Ether, for example "0.5 ETH".
+func (g Gwei) String() string {
+	return formatDecimal(new(big.Int).SetUint64(uint64(g)), 9) + " ETH"
+}
+
+// ParseWei parses an amount of Ether in to wei.
+// The amount is a decimal number with an optional unit of "ETH" or "Ether", "Gwei" or "wei", for example "32",
+// "0.5 ETH" or "1000000000 gwei".  Amounts without a unit are in Ether.
+// An error is returned if the amount is not a whole number of wei.
+func ParseWei(input string) (*Wei, error) {
+	res, err := parseWei(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*Wei)(res), nil
+}
+
+// NewWei creates an amount in wei.
+func NewWei(val *big.Int) (*Wei, error) {
+	if val == nil {
+		return nil, errors.New("no amount supplied")
+	}
+	if val.Sign() < 0 {
+		return nil, errors.New("amount must not be negative")
+	}
+
+	return (*Wei)(new(big.Int).Set(val)), nil
+}
+
+// BigInt returns the amount in wei as a big integer.
+func (w *Wei) BigInt() *big.Int {
+	return new(big.Int).Set((*big.Int)(w))
+}
+
+// Gwei returns the amount in Gwei.
+// An error is returned if the amount is not a whole number of Gwei, or is too large to be held.
+func (w *Wei) Gwei() (Gwei, error) {
+	quo, rem := new(big.Int).QuoRem((*big.Int)(w), weiPerGwei, new(big.Int))
+	if rem.Sign() != 0 {
+		return 0, errors.New("amount is not a whole number of Gwei")
+	}
+	if !quo.IsUint64() {
+		return 0, errors.New("amount overflows Gwei")
+	}
+
+	return Gwei(quo.Uint64()), nil
+}
+
+// String returns the amount in Ether, for example "0.5 ETH".
+func (w *Wei) String() string {
+	return formatDecimal((*big.Int)(w), 18) + " ETH"
+}
+
+// parseWei parses an amount of Ether with an optional unit in to wei.
+func parseWei(input string) (*big.Int, error) {
+	str := strings.TrimSpace(input)
+	if str == "" {
+		return nil, errors.New("no amount supplied")
+	}
+	if strings.HasPrefix(str, "-") {
+		return nil, fmt.Errorf("amount %q must not be negative", input)
+	}
+
+	number, unit := str, ""
+	if index := strings.IndexFunc(str, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	}); index != -1 {
+		number, unit = strings.TrimSpace(str[:index]), strings.TrimSpace(str[index:])
+	}
+	var decimals int
+	switch strings.ToLower(unit) {
+	case "", "eth", "ether":
+		decimals = 18
+	case "gwei":
+		decimals = 9
+	case "wei":
+		decimals = 0
+	default:
+		return nil, fmt.Errorf("amount %q has unknown unit %q", input, unit)
+	}
+
+	whole, fraction, _ := strings.Cut(number, ".")
+	if whole == "" && fraction == "" {
+		return nil, fmt.Errorf("amount %q has no value", input)
+	}
+	if strings.Contains(fraction, ".") {
+		return nil, fmt.Errorf("amount %q is not a decimal number", input)
+	}
+	if len(fraction) > decimals {
+		if strings.Trim(fraction[decimals:], "0") != "" {
+			return nil, fmt.Errorf("amount %q is not a whole number of wei", input)
+		}
+		fraction = fraction[:decimals]
+	}
+	res, success := new(big.Int).SetString(whole+fraction+strings.Repeat("0", decimals-len(fraction)), 10)
+	if !success {
+		return nil, fmt.Errorf("amount %q is not a decimal number", input)
+	}
+
+	return res, nil
+}
+
+// formatDecimal formats an integer value with the given number of decimal places, without trailing zeros.
+func formatDecimal(val *big.Int, decimals int) string {
+	str := val.String()
+	if len(str) <= decimals {
+		str = strings.Repeat("0", decimals-len(str)+1) + str
+	}
+	whole, fraction := str[:len(str)-decimals], strings.TrimRight(str[len(str)-decimals:], "0")
+	if fraction == "" {
+		return whole
+	}
+
+	return whole + "." + fraction
+}
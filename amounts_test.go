@@ -0,0 +1,186 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestParseGwei(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+		gwei  util.Gwei
+	}{
+		{
+			name: "Empty",
+			err:  "no amount supplied",
+		},
+		{
+			name:  "Negative",
+			input: "-1",
+			err:   `amount "-1" must not be negative`,
+		},
+		{
+			name:  "UnitUnknown",
+			input: "1 finney",
+			err:   `amount "1 finney" has unknown unit "finney"`,
+		},
+		{
+			name:  "ValueMissing",
+			input: "ETH",
+			err:   `amount "ETH" has no value`,
+		},
+		{
+			name:  "DecimalInvalid",
+			input: "1.2.3",
+			err:   `amount "1.2.3" is not a decimal number`,
+		},
+		{
+			name:  "FractionalWei",
+			input: "0.5 wei",
+			err:   `amount "0.5 wei" is not a whole number of wei`,
+		},
+		{
+			name:  "FractionalGwei",
+			input: "1.5 gwei",
+			err:   `invalid amount "1.5 gwei": amount is not a whole number of Gwei`,
+		},
+		{
+			name:  "Overflow",
+			input: "18446744073.709551616",
+			err:   `invalid amount "18446744073.709551616": amount overflows Gwei`,
+		},
+		{
+			name:  "Ether",
+			input: "32",
+			gwei:  32_000_000_000,
+		},
+		{
+			name:  "EtherUnit",
+			input: "0.5 ETH",
+			gwei:  500_000_000,
+		},
+		{
+			name:  "EtherUnitNoSpace",
+			input: "2048ether",
+			gwei:  2_048_000_000_000,
+		},
+		{
+			name:  "LeadingPoint",
+			input: " .25 Ether ",
+			gwei:  250_000_000,
+		},
+		{
+			name:  "TrailingZeros",
+			input: "1.000000000000000000000 ETH",
+			gwei:  1_000_000_000,
+		},
+		{
+			name:  "Gwei",
+			input: "1000000000 gwei",
+			gwei:  1_000_000_000,
+		},
+		{
+			name:  "Wei",
+			input: "3000000000 wei",
+			gwei:  3,
+		},
+		{
+			name:  "Maximum",
+			input: "18446744073.709551615",
+			gwei:  18_446_744_073_709_551_615,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gwei, err := util.ParseGwei(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.gwei, gwei)
+			}
+		})
+	}
+}
+
+func TestGweiString(t *testing.T) {
+	tests := []struct {
+		gwei util.Gwei
+		str  string
+	}{
+		{gwei: 0, str: "0 ETH"},
+		{gwei: 1, str: "0.000000001 ETH"},
+		{gwei: 500_000_000, str: "0.5 ETH"},
+		{gwei: 32_000_000_000, str: "32 ETH"},
+		{gwei: 32_123_456_789, str: "32.123456789 ETH"},
+		{gwei: 18_446_744_073_709_551_615, str: "18446744073.709551615 ETH"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			assert.Equal(t, test.str, test.gwei.String())
+			gwei, err := util.ParseGwei(test.gwei.String())
+			require.NoError(t, err)
+			assert.Equal(t, test.gwei, gwei)
+		})
+	}
+}
+
+func TestWei(t *testing.T) {
+	_, err := util.ParseWei("1.5 wei")
+	require.EqualError(t, err, `amount "1.5 wei" is not a whole number of wei`)
+	_, err = util.NewWei(nil)
+	require.EqualError(t, err, "no amount supplied")
+	_, err = util.NewWei(big.NewInt(-1))
+	require.EqualError(t, err, "amount must not be negative")
+
+	wei, err := util.ParseWei("1.000000000000000001 ETH")
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000000000001", wei.BigInt().String())
+	assert.Equal(t, "1.000000000000000001 ETH", wei.String())
+	_, err = wei.Gwei()
+	require.EqualError(t, err, "amount is not a whole number of Gwei")
+
+	wei, err = util.ParseWei("100000000000 ETH")
+	require.NoError(t, err)
+	assert.Equal(t, "100000000000 ETH", wei.String())
+	_, err = wei.Gwei()
+	require.EqualError(t, err, "amount overflows Gwei")
+
+	wei, err = util.NewWei(big.NewInt(1_500_000_000_000_000_000))
+	require.NoError(t, err)
+	assert.Equal(t, "1.5 ETH", wei.String())
+	gwei, err := wei.Gwei()
+	require.NoError(t, err)
+	assert.Equal(t, util.Gwei(1_500_000_000), gwei)
+	assert.Equal(t, wei.BigInt(), gwei.Wei().BigInt())
+
+	// The amount is copied.
+	val := big.NewInt(1)
+	wei, err = util.NewWei(val)
+	require.NoError(t, err)
+	val.SetInt64(2)
+	assert.Equal(t, "1", wei.BigInt().String())
+	wei.BigInt().SetInt64(3)
+	assert.Equal(t, "1", wei.BigInt().String())
+}
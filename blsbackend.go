@@ -0,0 +1,97 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/pkg/errors"
+)
+
+// BLSBackend is a BLS signature library.
+// The herumi backend, which requires cgo, is the default; the reference backend is a slower pure-Go implementation
+// for testing and for comparing results across libraries.
+type BLSBackend interface {
+	// Name returns the name of the backend.
+	Name() string
+	// PrivateKeyFromBytes creates a private key from its 32-byte big-endian scalar.
+	PrivateKeyFromBytes(data []byte) (BLSPrivateKey, error)
+	// PublicKeyFromBytes creates a public key from its 48-byte compressed form.
+	PublicKeyFromBytes(data []byte) (BLSPublicKey, error)
+	// SignatureFromBytes creates a signature from its 96-byte compressed form.
+	SignatureFromBytes(data []byte) (BLSSignature, error)
+	// AggregatePublicKeys aggregates public keys.
+	AggregatePublicKeys(pubKeys []BLSPublicKey) (BLSPublicKey, error)
+	// AggregateSignatures aggregates signatures.
+	AggregateSignatures(signatures []BLSSignature) (BLSSignature, error)
+}
+
+// BLSPrivateKey is a private key created by a BLS backend.
+type BLSPrivateKey interface {
+	// Marshal returns the 32-byte big-endian scalar of the key.
+	Marshal() []byte
+	// PublicKey returns the public key of the key.
+	PublicKey() BLSPublicKey
+	// Sign signs a message.
+	Sign(msg []byte) BLSSignature
+}
+
+// BLSPublicKey is a public key created by a BLS backend.
+type BLSPublicKey interface {
+	// Marshal returns the 48-byte compressed form of the key.
+	Marshal() []byte
+}
+
+// BLSSignature is a signature created by a BLS backend.
+type BLSSignature interface {
+	// Marshal returns the 96-byte compressed form of the signature.
+	Marshal() []byte
+	// Verify verifies the signature of a message against a public key, which can be from any backend.
+	Verify(msg []byte, pubKey BLSPublicKey) bool
+}
+
+// defaultBLSBackend is the default backend, registered by the herumi backend when built with cgo.
+var defaultBLSBackend BLSBackend
+
+// DefaultBLSBackend returns the default BLS backend.
+// This is the herumi backend, or nil if the package is built without cgo.
+func DefaultBLSBackend() BLSBackend {
+	return defaultBLSBackend
+}
+
+// blsBackend returns the given backend, or the default backend if none is given.
+func blsBackend(backend BLSBackend) (BLSBackend, error) {
+	if backend != nil {
+		return backend, nil
+	}
+	if defaultBLSBackend == nil {
+		return nil, errors.New("no BLS backend available")
+	}
+
+	return defaultBLSBackend, nil
+}
+
+// PrivateKeyFromSeedAndPathWithBackend generates a private key with the given BLS backend given a seed and a path.
+// If backend is nil the default backend is used.
+// Follows ERC-2334.
+func PrivateKeyFromSeedAndPathWithBackend(backend BLSBackend, seed []byte, path string) (BLSPrivateKey, error) {
+	backend, err := blsBackend(backend)
+	if err != nil {
+		return nil, err
+	}
+	data, err := PrivateKeyBytesFromSeedAndPath(seed, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.PrivateKeyFromBytes(data)
+}
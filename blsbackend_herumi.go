@@ -0,0 +1,174 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func init() {
+	defaultBLSBackend = HerumiBLSBackend()
+}
+
+type herumiBackend struct{}
+
+// HerumiBLSBackend returns the BLS backend provided by the herumi library through go-eth2-types.
+func HerumiBLSBackend() BLSBackend {
+	return herumiBackend{}
+}
+
+// Name returns the name of the backend.
+func (herumiBackend) Name() string {
+	return "herumi"
+}
+
+// PrivateKeyFromBytes creates a private key from its 32-byte big-endian scalar.
+func (herumiBackend) PrivateKeyFromBytes(data []byte) (BLSPrivateKey, error) {
+	key, err := e2types.BLSPrivateKeyFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return HerumiPrivateKey(key), nil
+}
+
+// PublicKeyFromBytes creates a public key from its 48-byte compressed form.
+func (herumiBackend) PublicKeyFromBytes(data []byte) (BLSPublicKey, error) {
+	key, err := e2types.BLSPublicKeyFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &herumiPublicKey{key: key}, nil
+}
+
+// SignatureFromBytes creates a signature from its 96-byte compressed form.
+func (herumiBackend) SignatureFromBytes(data []byte) (BLSSignature, error) {
+	sig, err := e2types.BLSSignatureFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &herumiSignature{sig: sig}, nil
+}
+
+// AggregatePublicKeys aggregates public keys.
+func (b herumiBackend) AggregatePublicKeys(pubKeys []BLSPublicKey) (BLSPublicKey, error) {
+	if len(pubKeys) == 0 {
+		return nil, errors.New("no public keys supplied")
+	}
+	var res e2types.PublicKey
+	for i := range pubKeys {
+		key, err := b.publicKey(pubKeys[i])
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			res = key.Copy()
+		} else {
+			res.Aggregate(key)
+		}
+	}
+
+	return &herumiPublicKey{key: res}, nil
+}
+
+// AggregateSignatures aggregates signatures.
+func (herumiBackend) AggregateSignatures(signatures []BLSSignature) (BLSSignature, error) {
+	if len(signatures) == 0 {
+		return nil, errors.New("no signatures supplied")
+	}
+	sigs := make([]e2types.Signature, len(signatures))
+	for i := range signatures {
+		if sig, isHerumi := signatures[i].(*herumiSignature); isHerumi {
+			sigs[i] = sig.sig
+			continue
+		}
+		sig, err := e2types.BLSSignatureFromBytes(signatures[i].Marshal())
+		if err != nil {
+			return nil, err
+		}
+		sigs[i] = sig
+	}
+
+	return &herumiSignature{sig: e2types.AggregateSignatures(sigs)}, nil
+}
+
+// publicKey returns the herumi form of a public key from any backend.
+func (herumiBackend) publicKey(pubKey BLSPublicKey) (e2types.PublicKey, error) {
+	if key, isHerumi := pubKey.(*herumiPublicKey); isHerumi {
+		return key.key, nil
+	}
+
+	return e2types.BLSPublicKeyFromBytes(pubKey.Marshal())
+}
+
+type herumiPrivateKey struct {
+	key *e2types.BLSPrivateKey
+}
+
+// HerumiPrivateKey returns a go-eth2-types private key, as returned by PrivateKeyFromSeedAndPath, as a backend
+// private key.
+func HerumiPrivateKey(key *e2types.BLSPrivateKey) BLSPrivateKey {
+	return &herumiPrivateKey{key: key}
+}
+
+// Marshal returns the 32-byte big-endian scalar of the key.
+func (k *herumiPrivateKey) Marshal() []byte {
+	return k.key.Marshal()
+}
+
+// PublicKey returns the public key of the key.
+func (k *herumiPrivateKey) PublicKey() BLSPublicKey {
+	return &herumiPublicKey{key: k.key.PublicKey()}
+}
+
+// Sign signs a message.
+func (k *herumiPrivateKey) Sign(msg []byte) BLSSignature {
+	return &herumiSignature{sig: k.key.Sign(msg)}
+}
+
+type herumiPublicKey struct {
+	key e2types.PublicKey
+}
+
+// Marshal returns the 48-byte compressed form of the key.
+func (k *herumiPublicKey) Marshal() []byte {
+	return k.key.Marshal()
+}
+
+type herumiSignature struct {
+	sig e2types.Signature
+}
+
+// Marshal returns the 96-byte compressed form of the signature.
+func (s *herumiSignature) Marshal() []byte {
+	return s.sig.Marshal()
+}
+
+// Verify verifies the signature of a message against a public key.
+func (s *herumiSignature) Verify(msg []byte, pubKey BLSPublicKey) bool {
+	if pubKey == nil {
+		return false
+	}
+	key, err := herumiBackend{}.publicKey(pubKey)
+	if err != nil {
+		return false
+	}
+
+	return s.sig.Verify(msg, key)
+}
@@ -0,0 +1,108 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestDefaultBLSBackend(t *testing.T) {
+	require.NotNil(t, util.DefaultBLSBackend())
+	assert.Equal(t, "herumi", util.DefaultBLSBackend().Name())
+
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	key, err := util.PrivateKeyFromSeedAndPathWithBackend(nil, seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	expected, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	assert.Equal(t, expected.Marshal(), key.Marshal())
+}
+
+// TestBLSBackendsAgree checks that the herumi and reference backends produce and accept the same keys and signatures.
+func TestBLSBackendsAgree(t *testing.T) {
+	herumi := util.HerumiBLSBackend()
+	reference := util.ReferenceBLSBackend()
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+
+	herumiSigs := make([]util.BLSSignature, 0)
+	referenceSigs := make([]util.BLSSignature, 0)
+	herumiPubKeys := make([]util.BLSPublicKey, 0)
+	referencePubKeys := make([]util.BLSPublicKey, 0)
+	msg := []byte("common message")
+	for account := uint32(0); account < 4; account++ {
+		path := util.SigningPath(account)
+		herumiKey, err := util.PrivateKeyFromSeedAndPathWithBackend(herumi, seed, path)
+		require.NoError(t, err)
+		referenceKey, err := util.PrivateKeyFromSeedAndPathWithBackend(reference, seed, path)
+		require.NoError(t, err)
+		require.Equal(t, herumiKey.PublicKey().Marshal(), referenceKey.PublicKey().Marshal())
+
+		accountMsg := []byte(fmt.Sprintf("message %d", account))
+		herumiSig := herumiKey.Sign(accountMsg)
+		referenceSig := referenceKey.Sign(accountMsg)
+		require.Equal(t, herumiSig.Marshal(), referenceSig.Marshal())
+		assert.True(t, herumiSig.Verify(accountMsg, referenceKey.PublicKey()))
+		assert.True(t, referenceSig.Verify(accountMsg, herumiKey.PublicKey()))
+
+		herumiSigs = append(herumiSigs, herumiKey.Sign(msg))
+		referenceSigs = append(referenceSigs, referenceKey.Sign(msg))
+		herumiPubKeys = append(herumiPubKeys, herumiKey.PublicKey())
+		referencePubKeys = append(referencePubKeys, referenceKey.PublicKey())
+	}
+
+	herumiAggregateSig, err := herumi.AggregateSignatures(herumiSigs)
+	require.NoError(t, err)
+	referenceAggregateSig, err := reference.AggregateSignatures(herumiSigs)
+	require.NoError(t, err)
+	require.Equal(t, herumiAggregateSig.Marshal(), referenceAggregateSig.Marshal())
+	herumiAggregatePubKey, err := herumi.AggregatePublicKeys(referencePubKeys)
+	require.NoError(t, err)
+	referenceAggregatePubKey, err := reference.AggregatePublicKeys(herumiPubKeys)
+	require.NoError(t, err)
+	require.Equal(t, herumiAggregatePubKey.Marshal(), referenceAggregatePubKey.Marshal())
+	assert.True(t, herumiAggregateSig.Verify(msg, referenceAggregatePubKey))
+	assert.True(t, referenceAggregateSig.Verify(msg, herumiAggregatePubKey))
+	_, err = herumi.AggregateSignatures(referenceSigs)
+	require.NoError(t, err)
+}
+
+func TestSignRootMatchesHelpers(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	key, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	referenceKey, err := util.PrivateKeyFromSeedAndPathWithBackend(util.ReferenceBLSBackend(), seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	forkVersion := []byte{0x00, 0x00, 0x00, 0x00}
+	genesisValidatorsRoot := make([]byte, 32)
+
+	proof, err := util.SlotSelectionProof(key, 10, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	slotRoot := make([]byte, 32)
+	slotRoot[0] = 10
+	referenceProof, err := util.SignRoot(referenceKey, slotRoot, e2types.DomainSelectionProof, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	assert.Equal(t, proof.Marshal(), referenceProof.Marshal())
+
+	herumiProof, err := util.SignRoot(util.HerumiPrivateKey(key), slotRoot, e2types.DomainSelectionProof, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	assert.Equal(t, proof.Marshal(), herumiProof.Marshal())
+}
@@ -0,0 +1,30 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cgo
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestDefaultBLSBackendWithoutCgo(t *testing.T) {
+	assert.Nil(t, util.DefaultBLSBackend())
+	_, err := util.PrivateKeyFromSeedAndPathWithBackend(nil, _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m")
+	require.EqualError(t, err, "no BLS backend available")
+}
@@ -0,0 +1,199 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"math/big"
+
+	bls12381 "github.com/kilic/bls12-381"
+	"github.com/pkg/errors"
+)
+
+// blsDST is the domain separation tag of the Ethereum consensus BLS signature scheme.
+var blsDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+type referenceBackend struct{}
+
+// ReferenceBLSBackend returns a pure-Go BLS backend built on the kilic/bls12-381 library.
+// It does not require cgo, but is slower than the herumi backend and is not hardened against side channels, so is
+// intended for testing and for comparing results across libraries.
+func ReferenceBLSBackend() BLSBackend {
+	return referenceBackend{}
+}
+
+// Name returns the name of the backend.
+func (referenceBackend) Name() string {
+	return "reference"
+}
+
+// PrivateKeyFromBytes creates a private key from its 32-byte big-endian scalar.
+func (referenceBackend) PrivateKeyFromBytes(data []byte) (BLSPrivateKey, error) {
+	if len(data) != 32 {
+		return nil, errors.New("private key must be 32 bytes")
+	}
+	sk := osToIP(data)
+	if sk.Sign() == 0 || sk.Cmp(r) >= 0 {
+		return nil, errors.New("invalid private key")
+	}
+
+	return &referencePrivateKey{sk: sk}, nil
+}
+
+// PublicKeyFromBytes creates a public key from its 48-byte compressed form.
+func (referenceBackend) PublicKeyFromBytes(data []byte) (BLSPublicKey, error) {
+	g1 := bls12381.NewG1()
+	point, err := g1.FromCompressed(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	if g1.IsZero(point) {
+		return nil, errors.New("public key is infinity")
+	}
+
+	return &referencePublicKey{point: point}, nil
+}
+
+// SignatureFromBytes creates a signature from its 96-byte compressed form.
+func (referenceBackend) SignatureFromBytes(data []byte) (BLSSignature, error) {
+	point, err := bls12381.NewG2().FromCompressed(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+
+	return &referenceSignature{point: point}, nil
+}
+
+// AggregatePublicKeys aggregates public keys.
+func (b referenceBackend) AggregatePublicKeys(pubKeys []BLSPublicKey) (BLSPublicKey, error) {
+	if len(pubKeys) == 0 {
+		return nil, errors.New("no public keys supplied")
+	}
+	g1 := bls12381.NewG1()
+	res := g1.Zero()
+	for i := range pubKeys {
+		key, err := b.publicKey(pubKeys[i])
+		if err != nil {
+			return nil, err
+		}
+		g1.Add(res, res, key.point)
+	}
+
+	return &referencePublicKey{point: res}, nil
+}
+
+// AggregateSignatures aggregates signatures.
+func (b referenceBackend) AggregateSignatures(signatures []BLSSignature) (BLSSignature, error) {
+	if len(signatures) == 0 {
+		return nil, errors.New("no signatures supplied")
+	}
+	g2 := bls12381.NewG2()
+	res := g2.Zero()
+	for i := range signatures {
+		sig, isReference := signatures[i].(*referenceSignature)
+		if !isReference {
+			converted, err := b.SignatureFromBytes(signatures[i].Marshal())
+			if err != nil {
+				return nil, err
+			}
+			sig = converted.(*referenceSignature)
+		}
+		g2.Add(res, res, sig.point)
+	}
+
+	return &referenceSignature{point: res}, nil
+}
+
+// publicKey returns the reference form of a public key from any backend.
+func (b referenceBackend) publicKey(pubKey BLSPublicKey) (*referencePublicKey, error) {
+	if key, isReference := pubKey.(*referencePublicKey); isReference {
+		return key, nil
+	}
+	key, err := b.PublicKeyFromBytes(pubKey.Marshal())
+	if err != nil {
+		return nil, err
+	}
+
+	return key.(*referencePublicKey), nil
+}
+
+type referencePrivateKey struct {
+	sk *big.Int
+}
+
+// Marshal returns the 32-byte big-endian scalar of the key.
+func (k *referencePrivateKey) Marshal() []byte {
+	return i2OSP(k.sk, 32)
+}
+
+// PublicKey returns the public key of the key.
+func (k *referencePrivateKey) PublicKey() BLSPublicKey {
+	g1 := bls12381.NewG1()
+
+	return &referencePublicKey{point: g1.MulScalarBig(g1.New(), g1.One(), k.sk)}
+}
+
+// Sign signs a message.
+func (k *referencePrivateKey) Sign(msg []byte) BLSSignature {
+	g2 := bls12381.NewG2()
+	point, err := g2.HashToCurve(msg, blsDST)
+	if err != nil {
+		// Hashing to the curve only fails if the domain separation tag is too long.
+		panic(err)
+	}
+
+	return &referenceSignature{point: g2.MulScalarBig(g2.New(), point, k.sk)}
+}
+
+type referencePublicKey struct {
+	point *bls12381.PointG1
+}
+
+// Marshal returns the 48-byte compressed form of the key.
+func (k *referencePublicKey) Marshal() []byte {
+	return bls12381.NewG1().ToCompressed(k.point)
+}
+
+type referenceSignature struct {
+	point *bls12381.PointG2
+}
+
+// Marshal returns the 96-byte compressed form of the signature.
+func (s *referenceSignature) Marshal() []byte {
+	return bls12381.NewG2().ToCompressed(s.point)
+}
+
+// Verify verifies the signature of a message against a public key.
+func (s *referenceSignature) Verify(msg []byte, pubKey BLSPublicKey) bool {
+	if pubKey == nil {
+		return false
+	}
+	key, err := referenceBackend{}.publicKey(pubKey)
+	if err != nil {
+		return false
+	}
+	if bls12381.NewG1().IsZero(key.point) {
+		return false
+	}
+	point, err := bls12381.NewG2().HashToCurve(msg, blsDST)
+	if err != nil {
+		return false
+	}
+
+	// Check e(pk, H(msg)) == e(g1, sig).
+	engine := bls12381.NewEngine()
+	engine.AddPair(key.point, point)
+	engine.AddPairInv(engine.G1.One(), s.point)
+
+	return engine.Check()
+}
@@ -0,0 +1,111 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestReferenceBLSBackend(t *testing.T) {
+	backend := util.ReferenceBLSBackend()
+	assert.Equal(t, "reference", backend.Name())
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+
+	key, err := util.PrivateKeyFromSeedAndPathWithBackend(backend, seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	assert.Equal(t, _byteArray("357ef801c5b8506ad84b8bc913251b8018886c35b7aa59509663ac00f4e1465a"), key.Marshal())
+	assert.Equal(t, _byteArray("b3d758f5ff8d1bdfe4b744e2372b5f37261619f4a45c97db829675e4669732781c858caaec6dbe86c2d096070de5c992"), key.PublicKey().Marshal())
+
+	msg := []byte("message")
+	sig := key.Sign(msg)
+	assert.True(t, sig.Verify(msg, key.PublicKey()))
+	assert.False(t, sig.Verify([]byte("other"), key.PublicKey()))
+	assert.False(t, sig.Verify(msg, nil))
+
+	pubKey, err := backend.PublicKeyFromBytes(key.PublicKey().Marshal())
+	require.NoError(t, err)
+	parsedSig, err := backend.SignatureFromBytes(sig.Marshal())
+	require.NoError(t, err)
+	assert.True(t, parsedSig.Verify(msg, pubKey))
+
+	key2, err := util.PrivateKeyFromSeedAndPathWithBackend(backend, seed, "m/12381/3600/1/0/0")
+	require.NoError(t, err)
+	aggregateSig, err := backend.AggregateSignatures([]util.BLSSignature{sig, key2.Sign(msg)})
+	require.NoError(t, err)
+	aggregatePubKey, err := backend.AggregatePublicKeys([]util.BLSPublicKey{key.PublicKey(), key2.PublicKey()})
+	require.NoError(t, err)
+	assert.True(t, aggregateSig.Verify(msg, aggregatePubKey))
+	assert.False(t, aggregateSig.Verify(msg, key.PublicKey()))
+}
+
+func TestReferenceBLSBackendErrors(t *testing.T) {
+	backend := util.ReferenceBLSBackend()
+
+	_, err := backend.PrivateKeyFromBytes([]byte{0x01})
+	require.EqualError(t, err, "private key must be 32 bytes")
+	_, err = backend.PrivateKeyFromBytes(make([]byte, 32))
+	require.EqualError(t, err, "invalid private key")
+	_, err = backend.PrivateKeyFromBytes(bytes.Repeat([]byte{0xff}, 32))
+	require.EqualError(t, err, "invalid private key")
+
+	_, err = backend.PublicKeyFromBytes([]byte{0x01})
+	require.EqualError(t, err, "invalid public key: input string length must be equal to 48 bytes")
+	_, err = backend.PublicKeyFromBytes(append([]byte{0xc0}, make([]byte, 47)...))
+	require.EqualError(t, err, "public key is infinity")
+
+	_, err = backend.SignatureFromBytes([]byte{0x01})
+	require.EqualError(t, err, "invalid signature: input string length must be equal to 96 bytes")
+
+	_, err = backend.AggregatePublicKeys(nil)
+	require.EqualError(t, err, "no public keys supplied")
+	_, err = backend.AggregateSignatures(nil)
+	require.EqualError(t, err, "no signatures supplied")
+
+	_, err = util.PrivateKeyFromSeedAndPathWithBackend(backend, []byte{0x01}, "m")
+	require.EqualError(t, err, "seed must be at least 128 bits")
+}
+
+func TestSignRoot(t *testing.T) {
+	key, err := util.PrivateKeyFromSeedAndPathWithBackend(util.ReferenceBLSBackend(),
+		_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"),
+		"m/12381/3600/0/0/0",
+	)
+	require.NoError(t, err)
+	objectRoot := bytes.Repeat([]byte{0x01}, 32)
+	domainType := [4]byte{0x05, 0x00, 0x00, 0x00}
+	forkVersion := []byte{0x00, 0x00, 0x00, 0x00}
+	genesisValidatorsRoot := bytes.Repeat([]byte{0x02}, 32)
+
+	_, err = util.SignRoot(nil, objectRoot, domainType, forkVersion, genesisValidatorsRoot)
+	require.EqualError(t, err, "no key supplied")
+	_, err = util.SignRoot(key, objectRoot, domainType, forkVersion[:3], genesisValidatorsRoot)
+	require.EqualError(t, err, "failed to compute domain: fork version must be 4 bytes in length")
+	_, err = util.SignRoot(key, objectRoot, domainType, forkVersion, genesisValidatorsRoot[:31])
+	require.EqualError(t, err, "failed to compute domain: genesis validators root must be 32 bytes in length")
+	_, err = util.SignRoot(key, objectRoot[:31], domainType, forkVersion, genesisValidatorsRoot)
+	require.EqualError(t, err, "object root must be 32 bytes")
+
+	sig, err := util.SignRoot(key, objectRoot, domainType, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	// Domain calculated by hand from the domain type and the fork data root.
+	domain := append(domainType[:], util.SHA256(append(forkVersion, make([]byte, 28)...), genesisValidatorsRoot)[:28]...)
+	signingRoot, err := util.ComputeSigningRoot(objectRoot, domain)
+	require.NoError(t, err)
+	assert.True(t, sig.Verify(signingRoot, key.PublicKey()))
+}
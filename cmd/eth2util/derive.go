@@ -0,0 +1,246 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	bip39 "github.com/tyler-smith/go-bip39"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+// maxAccountRange is the maximum number of accounts in an account range, which bounds the work and memory of a
+// single command.
+const maxAccountRange = 100000
+
+// runDerive runs the derive command.
+func runDerive(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("derive", flag.ContinueOnError)
+	flags.SetOutput(out)
+	mnemonic := flags.String("mnemonic", "", "mnemonic from which to derive keys")
+	passphrase := flags.String("passphrase", "", "passphrase for the mnemonic")
+	seedStr := flags.String("seed", "", "hex seed from which to derive keys")
+	path := flags.String("path", "", "path, or path template such as m/12381/3600/{0..9}/0/0, of the keys to derive")
+	accounts := flags.String("accounts", "", "account index, or range of indices such as 0-9, for which to derive signing and withdrawal keys")
+	privateKeys := flags.Bool("private-keys", false, "include private keys in the output")
+	format := flags.String("format", "text", "output format: text, json or csv")
+	flags.Usage = func() {
+		fmt.Fprintln(out, "Usage: eth2util derive (--mnemonic <mnemonic> | --seed <seed>) (--path <path> | --accounts <range>) [options]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+
+		return err
+	}
+
+	seed, err := obtainSeed(*mnemonic, *passphrase, *seedStr)
+	if err != nil {
+		return err
+	}
+
+	var output *table
+	switch {
+	case *path != "" && *accounts != "":
+		return errors.New("only one of path and accounts can be supplied")
+	case *path != "":
+		output, err = derivePath(seed, *path, *privateKeys)
+	case *accounts != "":
+		var first, last uint32
+		first, last, err = parseAccountRange(*accounts)
+		if err != nil {
+			return err
+		}
+		output, err = deriveAccounts(seed, first, last, *privateKeys)
+	default:
+		return errors.New("one of path and accounts must be supplied")
+	}
+	if err != nil {
+		return err
+	}
+
+	return output.write(out, *format)
+}
+
+// obtainSeed obtains the seed from either a mnemonic or a hex string.
+func obtainSeed(mnemonic string, passphrase string, seedStr string) ([]byte, error) {
+	switch {
+	case mnemonic != "" && seedStr != "":
+		return nil, errors.New("only one of mnemonic and seed can be supplied")
+	case mnemonic != "":
+		// Normalise whitespace and case, as mnemonics are often copied from written records.
+		mnemonic = strings.ToLower(strings.Join(strings.Fields(mnemonic), " "))
+		seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid mnemonic")
+		}
+
+		return seed, nil
+	case seedStr != "":
+		if passphrase != "" {
+			return nil, errors.New("passphrase can only be supplied with a mnemonic")
+		}
+		seed, err := hex.DecodeString(strings.TrimPrefix(seedStr, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid seed")
+		}
+
+		return seed, nil
+	default:
+		return nil, errors.New("one of mnemonic and seed must be supplied")
+	}
+}
+
+// parseAccountRange parses an account index such as "5" or an inclusive range such as "0-9".
+// Ranges of more than maxAccountRange accounts are rejected.
+func parseAccountRange(input string) (uint32, uint32, error) {
+	firstStr, lastStr, isRange := strings.Cut(input, "-")
+	first, err := strconv.ParseUint(strings.TrimSpace(firstStr), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid account range %q", input)
+	}
+	if !isRange {
+		return uint32(first), uint32(first), nil
+	}
+	last, err := strconv.ParseUint(strings.TrimSpace(lastStr), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid account range %q", input)
+	}
+	if last < first {
+		return 0, 0, fmt.Errorf("invalid account range %q", input)
+	}
+	if last-first >= maxAccountRange {
+		return 0, 0, fmt.Errorf("account range %q covers more than %d accounts", input, maxAccountRange)
+	}
+
+	return uint32(first), uint32(last), nil
+}
+
+// derivePath derives the keys at the paths of a path template.
+// Withdrawal credentials are only supplied for keys at ERC-2334 withdrawal paths, as only those keys are used for
+// withdrawals.
+func derivePath(seed []byte, template string, privateKeys bool) (*table, error) {
+	keys, err := util.PrivateKeysFromSeedAndPathTemplate(seed, template)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive key")
+	}
+
+	res := &table{
+		fields:  []string{"path", "pubkey", "withdrawal_credentials", "private_key"},
+		records: make([][]string, 0, len(keys)),
+	}
+	for _, key := range keys {
+		pubKey := key.PrivateKey.PublicKey().Marshal()
+		record := []string{key.Path, hexString(pubKey), "", ""}
+		if isWithdrawalPath(key.Path) {
+			withdrawalCredentials, err := util.BLSWithdrawalCredentials(pubKey)
+			if err != nil {
+				return nil, err
+			}
+			record[2] = hexString(withdrawalCredentials)
+		}
+		if privateKeys {
+			record[3] = privateKeyString(key.PrivateKey)
+		}
+		res.records = append(res.records, record)
+	}
+
+	return res, nil
+}
+
+// isWithdrawalPath returns true if the path is an ERC-2334 withdrawal path, m/12381/3600/i/0.
+func isWithdrawalPath(path string) bool {
+	components := strings.Split(path, "/")
+	if len(components) != 5 {
+		return false
+	}
+	account, err := strconv.ParseUint(components[3], 10, 32)
+	if err != nil {
+		return false
+	}
+
+	return path == util.WithdrawalPath(uint32(account))
+}
+
+// deriveAccounts derives the signing and withdrawal keys for a range of accounts.
+func deriveAccounts(seed []byte, first uint32, last uint32, privateKeys bool) (*table, error) {
+	res := &table{
+		fields: []string{
+			"account",
+			"signing_path",
+			"signing_pubkey",
+			"withdrawal_path",
+			"withdrawal_pubkey",
+			"withdrawal_credentials",
+			"signing_private_key",
+			"withdrawal_private_key",
+		},
+		records: make([][]string, 0, uint64(last)-uint64(first)+1),
+	}
+	deriver, err := util.NewKeyDeriver(seed)
+	if err != nil {
+		return nil, err
+	}
+	for account := uint64(first); account <= uint64(last); account++ {
+		withdrawalPath := util.WithdrawalPath(uint32(account))
+		withdrawalKey, err := deriver.PrivateKey(withdrawalPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to derive withdrawal key for account %d", account)
+		}
+		signingPath := util.SigningPath(uint32(account))
+		signingKey, err := deriver.PrivateKey(signingPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to derive signing key for account %d", account)
+		}
+		withdrawalCredentials, err := util.BLSWithdrawalCredentials(withdrawalKey.PublicKey().Marshal())
+		if err != nil {
+			return nil, err
+		}
+
+		record := []string{
+			strconv.FormatUint(account, 10),
+			signingPath,
+			hexString(signingKey.PublicKey().Marshal()),
+			withdrawalPath,
+			hexString(withdrawalKey.PublicKey().Marshal()),
+			hexString(withdrawalCredentials),
+			"",
+			"",
+		}
+		if privateKeys {
+			record[6] = privateKeyString(signingKey)
+			record[7] = privateKeyString(withdrawalKey)
+		}
+		res.records = append(res.records, record)
+	}
+
+	return res, nil
+}
+
+func privateKeyString(key *e2types.BLSPrivateKey) string {
+	return hexString(key.Marshal())
+}
+
+func hexString(data []byte) string {
+	return fmt.Sprintf("%#x", data)
+}
@@ -0,0 +1,224 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestMain(m *testing.M) {
+	if err := e2types.InitBLS(); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+func TestParseAccountRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+		first uint32
+		last  uint32
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			err:   `invalid account range ""`,
+		},
+		{
+			name:  "Negative",
+			input: "-1",
+			err:   `invalid account range "-1"`,
+		},
+		{
+			name:  "Reversed",
+			input: "9-0",
+			err:   `invalid account range "9-0"`,
+		},
+		{
+			name:  "Overflow",
+			input: "0-4294967296",
+			err:   `invalid account range "0-4294967296"`,
+		},
+		{
+			name:  "TooLarge",
+			input: "0-4294967295",
+			err:   `account range "0-4294967295" covers more than 100000 accounts`,
+		},
+		{
+			name:  "Maximum",
+			input: "4294867296-4294967295",
+			first: 4294867296,
+			last:  4294967295,
+		},
+		{
+			name:  "Single",
+			input: "5",
+			first: 5,
+			last:  5,
+		},
+		{
+			name:  "Range",
+			input: "0 - 9",
+			first: 0,
+			last:  9,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first, last, err := parseAccountRange(test.input)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.first, first)
+				assert.Equal(t, test.last, last)
+			}
+		})
+	}
+}
+
+func TestRunDerive(t *testing.T) {
+	seed := "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	tests := []struct {
+		name   string
+		args   []string
+		err    string
+		output string
+	}{
+		{
+			name: "NoSeed",
+			args: []string{"--path", "m/12381/3600/0/0/0"},
+			err:  "one of mnemonic and seed must be supplied",
+		},
+		{
+			name: "SeedAndMnemonic",
+			args: []string{"--seed", seed, "--mnemonic", mnemonic, "--path", "m/12381/3600/0/0/0"},
+			err:  "only one of mnemonic and seed can be supplied",
+		},
+		{
+			name: "BadMnemonic",
+			args: []string{"--mnemonic", strings.Replace(mnemonic, "about", "abandon", 1), "--path", "m/12381/3600/0/0/0"},
+			err:  "invalid mnemonic: Invalid mnenomic",
+		},
+		{
+			name: "SeedPassphrase",
+			args: []string{"--seed", seed, "--passphrase", "secret", "--path", "m/12381/3600/0/0/0"},
+			err:  "passphrase can only be supplied with a mnemonic",
+		},
+		{
+			name: "NoPath",
+			args: []string{"--seed", seed},
+			err:  "one of path and accounts must be supplied",
+		},
+		{
+			name: "PathAndAccounts",
+			args: []string{"--seed", seed, "--path", "m/12381/3600/0/0/0", "--accounts", "0"},
+			err:  "only one of path and accounts can be supplied",
+		},
+		{
+			name: "BadPath",
+			args: []string{"--seed", seed, "--path", "m/12381//0"},
+			err:  "failed to derive key: no entry at path component 2",
+		},
+		{
+			name: "BadFormat",
+			args: []string{"--seed", seed, "--path", "m/12381/3600/0/0/0", "--format", "xml"},
+			err:  `unsupported format "xml"`,
+		},
+		{
+			name: "Path",
+			args: []string{"--seed", seed, "--path", "m/12381/3600/0/0/0", "--private-keys"},
+			output: `path:                   m/12381/3600/0/0/0
+pubkey:                 0xb3d758f5ff8d1bdfe4b744e2372b5f37261619f4a45c97db829675e4669732781c858caaec6dbe86c2d096070de5c992
+private_key:            0x357ef801c5b8506ad84b8bc913251b8018886c35b7aa59509663ac00f4e1465a
+`,
+		},
+		{
+			name: "WithdrawalPath",
+			args: []string{"--seed", seed, "--path", "m/12381/3600/0/0", "--format", "csv"},
+			output: `path,pubkey,withdrawal_credentials,private_key
+m/12381/3600/0/0,0x98c29280e46c66ad074cc2bcb32721c78578b56a04848409f34e7409a687df335bbe112c0877b232177576847b6573e4,0x0056df2768a9f94363c8689df5ae090d8c1ab90fb502ffde1b151ab63daeb57f,
+`,
+		},
+		{
+			name: "AccountsCSV",
+			args: []string{"--mnemonic", "  ABANDON " + mnemonic[8:], "--accounts", "0-1", "--format", "csv"},
+			output: `account,signing_path,signing_pubkey,withdrawal_path,withdrawal_pubkey,withdrawal_credentials,signing_private_key,withdrawal_private_key
+0,m/12381/3600/0/0/0,0xb3e445d43871965d890a398f719348a1405ac72e35b92727cc570026f54471af7ea7b2040622a8fd0b5bfb2a209b5911,m/12381/3600/0/0,0x8ebe599559cbf3abbc6a72b25d8bc13fd9b5075283fcd9ec47b2a0bf6c5148a2e9e615b181e5b2c03abe63818ef70c61,0x00eca1f12f398e3ceef109f5f76d8e99f9105e800a90390f1a18895919fd4b3b,,
+1,m/12381/3600/1/0/0,0xaeb399bf5648b0e9980c1731824c269631a41320c3d7f730c40587e1a37a5e1c8b5755fd90080a7b3fb90d3fd419c0a7,m/12381/3600/1/0,0x8b52e53cb73723a8f60fe279edfb277f48ec3ae54070326113c7277decd0f1dd9fab5267dfe40604c2614028be7860b1,0x00477335d95376155e8f46b2fc1f227335fed21c702c9b457306c68b147333d2,,
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			err := runDerive(test.args, out)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.output, out.String())
+			}
+		})
+	}
+}
+
+func TestRunDeriveJSON(t *testing.T) {
+	out := new(bytes.Buffer)
+	err := runDerive([]string{
+		"--seed", "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		"--accounts", "2-4",
+		"--format", "json",
+	}, out)
+	require.NoError(t, err)
+
+	var records []map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &records))
+	require.Len(t, records, 3)
+	assert.Equal(t, "4", records[2]["account"])
+	assert.Equal(t, "m/12381/3600/4/0/0", records[2]["signing_path"])
+	assert.NotContains(t, records[2], "signing_private_key")
+}
+
+func TestRunDerivePathTemplate(t *testing.T) {
+	out := new(bytes.Buffer)
+	err := runDerive([]string{
+		"--mnemonic", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"--path", "m/12381/3600/{1,0}/0/0",
+		"--format", "json",
+	}, out)
+	require.NoError(t, err)
+
+	var records []map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &records))
+	require.Len(t, records, 2)
+	assert.Equal(t, "m/12381/3600/1/0/0", records[0]["path"])
+	assert.Equal(t, "0xaeb399bf5648b0e9980c1731824c269631a41320c3d7f730c40587e1a37a5e1c8b5755fd90080a7b3fb90d3fd419c0a7", records[0]["pubkey"])
+	assert.Equal(t, "m/12381/3600/0/0/0", records[1]["path"])
+	assert.Equal(t, "0xb3e445d43871965d890a398f719348a1405ac72e35b92727cc570026f54471af7ea7b2040622a8fd0b5bfb2a209b5911", records[1]["pubkey"])
+}
@@ -0,0 +1,111 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+// runHash runs the hash command.
+func runHash(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("hash", flag.ContinueOnError)
+	flags.SetOutput(out)
+	algorithm := flags.String("algorithm", "sha256", "hash algorithm: sha256, sha3-256, keccak256 or ssz")
+	hexStr := flags.String("hex", "", "hex data to hash")
+	str := flags.String("string", "", "string to hash")
+	file := flags.String("file", "", "file containing data to hash")
+	flags.Usage = func() {
+		fmt.Fprintln(out, "Usage: eth2util hash (--hex <data> | --string <data> | --file <path>) [options]")
+		fmt.Fprintln(out, "The ssz algorithm splits the data in to 32-byte chunks and prints their hash tree root.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+
+		return err
+	}
+
+	// Inputs are told apart by whether they are set rather than by their values, so that empty input can be hashed.
+	supplied := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		supplied[f.Name] = true
+	})
+	data, err := obtainHashInput(supplied, *hexStr, *str, *file)
+	if err != nil {
+		return err
+	}
+
+	var hash []byte
+	switch strings.ToLower(*algorithm) {
+	case "sha256":
+		hash = util.SHA256(data)
+	case "sha3-256", "sha3256":
+		hash = util.SHA3256(data)
+	case "keccak256":
+		hash = util.Keccak256(data)
+	case "ssz":
+		hash, err = util.Merkleize(util.Pack(data))
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported algorithm %q", *algorithm)
+	}
+
+	_, err = fmt.Fprintln(out, hexString(hash))
+
+	return err
+}
+
+// obtainHashInput obtains the data to hash from exactly one of its possible sources, given the flags supplied.
+func obtainHashInput(supplied map[string]bool, hexStr string, str string, file string) ([]byte, error) {
+	inputs := 0
+	for _, name := range []string{"hex", "string", "file"} {
+		if supplied[name] {
+			inputs++
+		}
+	}
+	if inputs != 1 {
+		return nil, errors.New("exactly one of hex, string and file must be supplied")
+	}
+
+	switch {
+	case supplied["hex"]:
+		data, err := hex.DecodeString(strings.TrimPrefix(hexStr, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid hex data")
+		}
+
+		return data, nil
+	case supplied["string"]:
+		return []byte(str), nil
+	default:
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read file")
+		}
+
+		return data, nil
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunHash(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "data")
+	require.NoError(t, os.WriteFile(file, []byte("abc"), 0o600))
+
+	tests := []struct {
+		name   string
+		args   []string
+		err    string
+		output string
+	}{
+		{
+			name: "NoInput",
+			err:  "exactly one of hex, string and file must be supplied",
+		},
+		{
+			name: "MultipleInputs",
+			args: []string{"--hex", "0x01", "--string", "abc"},
+			err:  "exactly one of hex, string and file must be supplied",
+		},
+		{
+			name: "MultipleInputsEmpty",
+			args: []string{"--hex", "", "--string", ""},
+			err:  "exactly one of hex, string and file must be supplied",
+		},
+		{
+			name: "BadHex",
+			args: []string{"--hex", "0xzz"},
+			err:  "invalid hex data: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		{
+			name: "MissingFile",
+			args: []string{"--file", filepath.Join(t.TempDir(), "missing")},
+			err:  "failed to read file",
+		},
+		{
+			name: "BadAlgorithm",
+			args: []string{"--string", "abc", "--algorithm", "md5"},
+			err:  `unsupported algorithm "md5"`,
+		},
+		{
+			name:   "SHA256",
+			args:   []string{"--string", "abc"},
+			output: "0xba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad\n",
+		},
+		{
+			name:   "SHA256Empty",
+			args:   []string{"--string", ""},
+			output: "0xe3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855\n",
+		},
+		{
+			name:   "SHA3256",
+			args:   []string{"--file", file, "--algorithm", "sha3-256"},
+			output: "0x3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532\n",
+		},
+		{
+			name:   "Keccak256",
+			args:   []string{"--hex", "0x", "--algorithm", "keccak256"},
+			output: "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470\n",
+		},
+		{
+			name: "SSZ",
+			args: []string{
+				"--hex",
+				"0x01010101010101010101010101010101010101010101010101010101010101010202020202020202020202020202020202020202020202020202020202020202",
+				"--algorithm", "ssz",
+			},
+			output: "0xf818afd37a6dc3bc92fb44731011277006db4efa6e9023cd7468c02335d22a4d\n",
+		},
+		{
+			name:   "SSZEmpty",
+			args:   []string{"--hex", "", "--algorithm", "ssz"},
+			output: "0x0000000000000000000000000000000000000000000000000000000000000000\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			err := runHash(test.args, out)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.output, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main provides the eth2util command, which exposes the utilities in this module on the command line.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// command is a subcommand of eth2util.
+type command struct {
+	name        string
+	description string
+	run         func(args []string, out io.Writer) error
+}
+
+//nolint:gochecknoglobals
+var commands = []*command{
+	{
+		name:        "derive",
+		description: "derive keys from a mnemonic or seed",
+		run:         runDerive,
+	},
+	{
+		name:        "hash",
+		description: "hash data",
+		run:         runHash,
+	},
+	{
+		name:        "web3signer",
+		description: "run a Web3Signer-compatible remote signer",
+		run:         runWeb3Signer,
+	},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs eth2util with the given arguments, returning the exit code.
+func run(args []string, out io.Writer, errOut io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(errOut)
+
+		return 2
+	}
+
+	if err := e2types.InitBLS(); err != nil {
+		fmt.Fprintf(errOut, "Failed to initialise BLS library: %v\n", err)
+
+		return 1
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			if err := cmd.run(args[1:], out); err != nil {
+				fmt.Fprintf(errOut, "%s: %v\n", cmd.name, err)
+
+				return 1
+			}
+
+			return 0
+		}
+	}
+
+	fmt.Fprintf(errOut, "Unknown command %q\n", args[0])
+	usage(errOut)
+
+	return 2
+}
+
+// usage prints the usage of eth2util.
+func usage(out io.Writer) {
+	fmt.Fprintln(out, "Usage: eth2util <command> [options]")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-10s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Run 'eth2util <command> -h' for the options of a command.")
+}
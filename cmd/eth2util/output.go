@@ -0,0 +1,122 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// table is a set of records with named fields.
+type table struct {
+	fields  []string
+	records [][]string
+}
+
+// write writes the table in the given format.
+func (t *table) write(out io.Writer, format string) error {
+	switch strings.ToLower(format) {
+	case "text":
+		return t.writeText(out)
+	case "json":
+		return t.writeJSON(out)
+	case "csv":
+		return t.writeCSV(out)
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// writeText writes each record as a block of name and value lines.
+func (t *table) writeText(out io.Writer) error {
+	width := 0
+	for _, field := range t.fields {
+		if len(field) > width {
+			width = len(field)
+		}
+	}
+	for i, record := range t.records {
+		if i > 0 {
+			if _, err := fmt.Fprintln(out); err != nil {
+				return err
+			}
+		}
+		for j, field := range t.fields {
+			if record[j] == "" {
+				continue
+			}
+			if _, err := fmt.Fprintf(out, "%-*s %s\n", width+1, field+":", record[j]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeJSON writes the records as an array of objects, keeping fields in order.
+func (t *table) writeJSON(out io.Writer) error {
+	buf := new(bytes.Buffer)
+	buf.WriteString("[")
+	for i, record := range t.records {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n  {")
+		first := true
+		for j, field := range t.fields {
+			if record[j] == "" {
+				continue
+			}
+			if !first {
+				buf.WriteString(",")
+			}
+			first = false
+			name, err := json.Marshal(field)
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(record[j])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(buf, "\n    %s: %s", name, value)
+		}
+		buf.WriteString("\n  }")
+	}
+	if len(t.records) > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]\n")
+	_, err := out.Write(buf.Bytes())
+
+	return err
+}
+
+// writeCSV writes the records with a header line.
+func (t *table) writeCSV(out io.Writer) error {
+	writer := csv.NewWriter(out)
+	if err := writer.Write(t.fields); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(t.records); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
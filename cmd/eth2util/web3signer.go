@@ -0,0 +1,158 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+	"github.com/wealdtech/go-eth2-util/slashingprotection"
+	"github.com/wealdtech/go-eth2-util/web3signer"
+)
+
+// web3SignerConfig is the configuration for the web3signer command.
+type web3SignerConfig struct {
+	mnemonic              string
+	passphrase            string
+	seed                  string
+	accounts              string
+	keystores             string
+	keystorePassphrase    string
+	slashingProtection    string
+	genesisValidatorsRoot string
+	genesisForkVersion    string
+	listen                string
+}
+
+// runWeb3Signer runs the web3signer command.
+func runWeb3Signer(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("web3signer", flag.ContinueOnError)
+	flags.SetOutput(out)
+	config := &web3SignerConfig{}
+	flags.StringVar(&config.mnemonic, "mnemonic", "", "mnemonic from which to derive keys")
+	flags.StringVar(&config.passphrase, "passphrase", "", "passphrase for the mnemonic")
+	flags.StringVar(&config.seed, "seed", "", "hex seed from which to derive keys")
+	flags.StringVar(&config.accounts, "accounts", "", "account index, or range of indices such as 0-9, for which to derive signing keys")
+	flags.StringVar(&config.keystores, "keystores", "", "comma-separated list of EIP-2335 keystore files")
+	flags.StringVar(&config.keystorePassphrase, "keystore-passphrase", "", "passphrase for the keystores")
+	flags.StringVar(&config.slashingProtection, "slashing-protection", "", "slashing protection file, created if it does not exist")
+	flags.StringVar(&config.genesisValidatorsRoot, "genesis-validators-root", "", "genesis validators root of the chain")
+	flags.StringVar(&config.genesisForkVersion, "genesis-fork-version", "", "genesis fork version of the chain, required to sign validator registrations")
+	flags.StringVar(&config.listen, "listen", "127.0.0.1:9000", "address on which to listen")
+	flags.Usage = func() {
+		fmt.Fprintln(out, "Usage: eth2util web3signer --slashing-protection <file> --genesis-validators-root <root> "+
+			"[(--mnemonic <mnemonic> | --seed <seed>) --accounts <range>] [--keystores <files>] [options]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+
+		return err
+	}
+
+	service, err := web3SignerService(config)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Listening on %s\n", config.listen)
+	server := &http.Server{
+		Addr:              config.listen,
+		Handler:           service,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	return server.ListenAndServe()
+}
+
+// web3SignerService creates the signing service from the configuration.
+func web3SignerService(config *web3SignerConfig) (*web3signer.Service, error) {
+	if config.slashingProtection == "" {
+		return nil, errors.New("slashing protection file must be supplied")
+	}
+	if config.genesisValidatorsRoot == "" {
+		return nil, errors.New("genesis validators root must be supplied")
+	}
+	genesisValidatorsRoot, err := hex.DecodeString(strings.TrimPrefix(config.genesisValidatorsRoot, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid genesis validators root")
+	}
+	var genesisForkVersion []byte
+	if config.genesisForkVersion != "" {
+		genesisForkVersion, err = hex.DecodeString(strings.TrimPrefix(config.genesisForkVersion, "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid genesis fork version")
+		}
+	}
+
+	keys := make([]*e2types.BLSPrivateKey, 0)
+	if config.accounts != "" {
+		seed, err := obtainSeed(config.mnemonic, config.passphrase, config.seed)
+		if err != nil {
+			return nil, err
+		}
+		first, last, err := parseAccountRange(config.accounts)
+		if err != nil {
+			return nil, err
+		}
+		derivedKeys, err := util.PrivateKeysFromSeedAndPathTemplate(seed, fmt.Sprintf("m/12381/3600/{%d..%d}/0/0", first, last))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to derive signing keys")
+		}
+		for _, derivedKey := range derivedKeys {
+			keys = append(keys, derivedKey.PrivateKey)
+		}
+	} else if config.mnemonic != "" || config.seed != "" {
+		return nil, errors.New("accounts must be supplied with a mnemonic or seed")
+	}
+	if config.keystores != "" {
+		for _, path := range strings.Split(config.keystores, ",") {
+			data, err := os.ReadFile(strings.TrimSpace(path))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read keystore")
+			}
+			key, err := util.PrivateKeyFromKeystore(data, config.keystorePassphrase)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decrypt keystore %s", path)
+			}
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no keys supplied")
+	}
+
+	store, err := slashingprotection.NewStore(config.slashingProtection, genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return web3signer.New(
+		web3signer.WithPrivateKeys(keys...),
+		web3signer.WithSlashingProtection(store),
+		web3signer.WithGenesisValidatorsRoot(genesisValidatorsRoot),
+		web3signer.WithGenesisForkVersion(genesisForkVersion),
+	)
+}
@@ -0,0 +1,100 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeb3SignerService(t *testing.T) {
+	gvr := "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	protection := filepath.Join(t.TempDir(), "protection.json")
+
+	tests := []struct {
+		name    string
+		config  *web3SignerConfig
+		err     string
+		pubKeys string
+	}{
+		{
+			name:   "SlashingProtectionMissing",
+			config: &web3SignerConfig{genesisValidatorsRoot: gvr},
+			err:    "slashing protection file must be supplied",
+		},
+		{
+			name:   "GenesisValidatorsRootMissing",
+			config: &web3SignerConfig{slashingProtection: protection},
+			err:    "genesis validators root must be supplied",
+		},
+		{
+			name:   "AccountsMissing",
+			config: &web3SignerConfig{slashingProtection: protection, genesisValidatorsRoot: gvr, seed: "0x01"},
+			err:    "accounts must be supplied with a mnemonic or seed",
+		},
+		{
+			name:   "KeysMissing",
+			config: &web3SignerConfig{slashingProtection: protection, genesisValidatorsRoot: gvr},
+			err:    "no keys supplied",
+		},
+		{
+			name: "KeystoreMissing",
+			config: &web3SignerConfig{
+				slashingProtection:    protection,
+				genesisValidatorsRoot: gvr,
+				keystores:             filepath.Join(t.TempDir(), "missing.json"),
+			},
+			err: "failed to read keystore",
+		},
+		{
+			name: "GenesisForkVersionInvalid",
+			config: &web3SignerConfig{
+				slashingProtection:    protection,
+				genesisValidatorsRoot: gvr,
+				genesisForkVersion:    "0xzz",
+			},
+			err: "invalid genesis fork version",
+		},
+		{
+			name: "Good",
+			config: &web3SignerConfig{
+				slashingProtection:    protection,
+				genesisValidatorsRoot: gvr,
+				genesisForkVersion:    "0x00000000",
+				seed:                  "0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+				accounts:              "0",
+			},
+			pubKeys: `["0xb3d758f5ff8d1bdfe4b744e2372b5f37261619f4a45c97db829675e4669732781c858caaec6dbe86c2d096070de5c992"]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, err := web3SignerService(test.config)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				rec := httptest.NewRecorder()
+				service.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/eth2/publicKeys", nil))
+				assert.Equal(t, test.pubKeys, rec.Body.String())
+			}
+		})
+	}
+}
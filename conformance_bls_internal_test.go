@@ -0,0 +1,65 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// TestTreeKDFVectorsPrivateKey derives the ERC-2333 test vectors in testdata as BLS private keys.
+func TestTreeKDFVectorsPrivateKey(t *testing.T) {
+	vectors, err := loadTreeKDFVectors(filepath.Join(vectorDir(), "tree_kdf.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, vectors)
+
+	for i, vector := range vectors {
+		t.Run(fmt.Sprintf("Vector%d", i), func(t *testing.T) {
+			key, err := PrivateKeyFromSeedAndPath(vector.Seed, fmt.Sprintf("m/%d", vector.ChildIndex))
+			require.NoError(t, err)
+			require.Equal(t, i2OSP(vector.ChildSK, 32), key.Marshal())
+		})
+	}
+}
+
+// TestKeystoreVectors decrypts the EIP-2335 test vectors in testdata.
+func TestKeystoreVectors(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	// The passphrase in the EIP, which is NFKD-normalised to "testpassword\U0001F511" before use.
+	passphrase := "𝔱𝔢𝔰𝔱𝔭𝔞𝔰𝔰𝔴𝔬𝔯𝔡🔑"
+	secret, err := hex.DecodeString("000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f")
+	require.NoError(t, err)
+	pubKey, err := hex.DecodeString("9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07")
+	require.NoError(t, err)
+
+	for _, name := range []string{"eip2335_scrypt.json", "eip2335_pbkdf2.json"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", name))
+			require.NoError(t, err)
+			key, err := PrivateKeyFromKeystore(data, passphrase)
+			require.NoError(t, err)
+			require.Equal(t, secret, key.Marshal())
+			require.Equal(t, pubKey, key.PublicKey().Marshal())
+		})
+	}
+}
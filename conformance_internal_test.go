@@ -0,0 +1,156 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	bytesutil "github.com/wealdtech/go-bytesutil"
+)
+
+// vectorBytes is a 0x-prefixed hex string in a test vector file.
+type vectorBytes []byte
+
+func (v *vectorBytes) UnmarshalJSON(input []byte) error {
+	var str string
+	if err := json.Unmarshal(input, &str); err != nil {
+		return err
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		return err
+	}
+	*v = data
+
+	return nil
+}
+
+// treeKDFVector is a test vector from the ERC-2333 tree_kdf.json file.
+type treeKDFVector struct {
+	Seed       vectorBytes `json:"seed"`
+	MasterSK   *big.Int    `json:"master_SK"`
+	ChildIndex uint32      `json:"child_index"`
+	ChildSK    *big.Int    `json:"child_SK"`
+}
+
+// treeKDFIntermediateVector is a test vector from the ERC-2333 tree_kdf_intermediate.json file.
+type treeKDFIntermediateVector struct {
+	treeKDFVector
+	Lamport0            []vectorBytes `json:"lamport_0"`
+	Lamport1            []vectorBytes `json:"lamport_1"`
+	CompressedLamportPK vectorBytes   `json:"compressed_lamport_PK"`
+}
+
+// vectorDir returns the directory holding the test vector files.
+// This defaults to the vectors in testdata, but can be pointed at a copy of the official files
+// with the ERC2333_TEST_VECTORS environment variable.
+func vectorDir() string {
+	if dir := os.Getenv("ERC2333_TEST_VECTORS"); dir != "" {
+		return dir
+	}
+
+	return "testdata"
+}
+
+// loadTreeKDFVectors loads the test vectors from a tree_kdf.json file.
+func loadTreeKDFVectors(path string) ([]*treeKDFVector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var vectors struct {
+		KDFTests []*treeKDFVector `json:"kdf_tests"`
+	}
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return vectors.KDFTests, nil
+}
+
+// loadTreeKDFIntermediateVector loads the test vector from a tree_kdf_intermediate.json file.
+func loadTreeKDFIntermediateVector(path string) (*treeKDFIntermediateVector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var vector treeKDFIntermediateVector
+	if err := json.Unmarshal(data, &vector); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return &vector, nil
+}
+
+// checkTreeKDFVector runs a test vector through master and child key derivation.
+func checkTreeKDFVector(t *testing.T, vector *treeKDFVector) {
+	t.Helper()
+
+	masterSK, err := DeriveMasterSK(vector.Seed)
+	require.NoError(t, err)
+	require.Equal(t, vector.MasterSK, masterSK)
+
+	childSK, err := DeriveChildSK(masterSK, vector.ChildIndex)
+	require.NoError(t, err)
+	require.Equal(t, vector.ChildSK, childSK)
+
+	sk, err := PrivateKeyBytesFromSeedAndPath(vector.Seed, fmt.Sprintf("m/%d", vector.ChildIndex))
+	require.NoError(t, err)
+	require.Equal(t, i2OSP(vector.ChildSK, 32), sk)
+}
+
+func TestTreeKDFVectors(t *testing.T) {
+	vectors, err := loadTreeKDFVectors(filepath.Join(vectorDir(), "tree_kdf.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, vectors)
+
+	for i, vector := range vectors {
+		t.Run(fmt.Sprintf("Vector%d", i), func(t *testing.T) {
+			checkTreeKDFVector(t, vector)
+		})
+	}
+}
+
+func TestTreeKDFIntermediateVector(t *testing.T) {
+	vector, err := loadTreeKDFIntermediateVector(filepath.Join(vectorDir(), "tree_kdf_intermediate.json"))
+	require.NoError(t, err)
+	checkTreeKDFVector(t, &vector.treeKDFVector)
+
+	salt := i2OSP(big.NewInt(int64(vector.ChildIndex)), 4)
+	ikm := i2OSP(vector.MasterSK, 32)
+	lamport0, err := ikmToLamportSK(ikm, salt)
+	require.NoError(t, err)
+	require.Len(t, vector.Lamport0, len(lamport0))
+	for i := range lamport0 {
+		require.Equal(t, []byte(vector.Lamport0[i]), lamport0[i][:], "lamport_0[%d]", i)
+	}
+	lamport1, err := ikmToLamportSK(bytesutil.XOR(ikm), salt)
+	require.NoError(t, err)
+	require.Len(t, vector.Lamport1, len(lamport1))
+	for i := range lamport1 {
+		require.Equal(t, []byte(vector.Lamport1[i]), lamport1[i][:], "lamport_1[%d]", i)
+	}
+
+	compressedLamportPK, err := parentSKToLamportPK(vector.MasterSK, vector.ChildIndex)
+	require.NoError(t, err)
+	require.Equal(t, []byte(vector.CompressedLamportPK), compressedLamportPK)
+}
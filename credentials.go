@@ -0,0 +1,96 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// BLSWithdrawalPrefix is the prefix for withdrawal credentials controlled by a BLS withdrawal key.
+const BLSWithdrawalPrefix = byte(0x00)
+
+// ExecutionWithdrawalPrefix is the prefix for withdrawal credentials controlled by an execution address.
+const ExecutionWithdrawalPrefix = byte(0x01)
+
+// CompoundingWithdrawalPrefix is the prefix for compounding withdrawal credentials controlled by an execution address.
+const CompoundingWithdrawalPrefix = byte(0x02)
+
+// BLSWithdrawalCredentials generates withdrawal credentials controlled by the BLS withdrawal key with the given public key.
+func BLSWithdrawalCredentials(pubKey []byte) ([]byte, error) {
+	if len(pubKey) != 48 {
+		return nil, errors.New("public key must be 48 bytes")
+	}
+
+	res := SHA256(pubKey)
+	res[0] = BLSWithdrawalPrefix
+
+	return res, nil
+}
+
+// ExecutionWithdrawalCredentials generates withdrawal credentials controlled by the given execution address.
+func ExecutionWithdrawalCredentials(address []byte) ([]byte, error) {
+	return executionWithdrawalCredentials(ExecutionWithdrawalPrefix, address)
+}
+
+// CompoundingWithdrawalCredentials generates compounding withdrawal credentials controlled by the given execution
+// address.
+// Validators with compounding withdrawal credentials have a maximum effective balance of MaxEffectiveBalanceElectra.
+func CompoundingWithdrawalCredentials(address []byte) ([]byte, error) {
+	return executionWithdrawalCredentials(CompoundingWithdrawalPrefix, address)
+}
+
+// executionWithdrawalCredentials generates withdrawal credentials with the given prefix for an execution address.
+func executionWithdrawalCredentials(prefix byte, address []byte) ([]byte, error) {
+	if len(address) != 20 {
+		return nil, errors.New("execution address must be 20 bytes")
+	}
+
+	res := make([]byte, 32)
+	res[0] = prefix
+	copy(res[12:], address)
+
+	return res, nil
+}
+
+// checkWithdrawalCredentials checks that withdrawal credentials have a known prefix and, for credentials controlled
+// by an execution address, are correctly padded.
+func checkWithdrawalCredentials(withdrawalCredentials []byte) error {
+	if len(withdrawalCredentials) != 32 {
+		return errors.New("withdrawal credentials must be 32 bytes")
+	}
+	switch withdrawalCredentials[0] {
+	case BLSWithdrawalPrefix:
+	case ExecutionWithdrawalPrefix, CompoundingWithdrawalPrefix:
+		for _, b := range withdrawalCredentials[1:12] {
+			if b != 0 {
+				return errors.New("execution withdrawal credentials must have 11 zero bytes after the prefix")
+			}
+		}
+	default:
+		return fmt.Errorf("unknown withdrawal credentials prefix %#02x", withdrawalCredentials[0])
+	}
+
+	return nil
+}
+
+// maxEffectiveBalance returns the maximum effective balance of a validator with the given withdrawal credentials.
+func maxEffectiveBalance(withdrawalCredentials []byte) Gwei {
+	if len(withdrawalCredentials) > 0 && withdrawalCredentials[0] == CompoundingWithdrawalPrefix {
+		return Gwei(MaxEffectiveBalanceElectra)
+	}
+
+	return Gwei(MaxEffectiveBalance)
+}
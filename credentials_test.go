@@ -0,0 +1,103 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestBLSWithdrawalCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		pubKey      []byte
+		err         string
+		credentials []byte
+	}{
+		{
+			name: "Nil",
+			err:  "public key must be 48 bytes",
+		},
+		{
+			name:   "Short",
+			pubKey: _byteArray("a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e4"),
+			err:    "public key must be 48 bytes",
+		},
+		{
+			name:        "Good",
+			pubKey:      _byteArray("a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+			credentials: _byteArray("00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			credentials, err := util.BLSWithdrawalCredentials(test.pubKey)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.credentials, credentials)
+			}
+		})
+	}
+}
+
+func TestExecutionWithdrawalCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		address     []byte
+		err         string
+		execution   []byte
+		compounding []byte
+	}{
+		{
+			name: "Nil",
+			err:  "execution address must be 20 bytes",
+		},
+		{
+			name:    "Short",
+			address: _byteArray("0102030405060708090a0b0c0d0e0f10111213"),
+			err:     "execution address must be 20 bytes",
+		},
+		{
+			name:        "Good",
+			address:     _byteArray("0102030405060708090a0b0c0d0e0f1011121314"),
+			execution:   _byteArray("0100000000000000000000000102030405060708090a0b0c0d0e0f1011121314"),
+			compounding: _byteArray("0200000000000000000000000102030405060708090a0b0c0d0e0f1011121314"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			execution, err := util.ExecutionWithdrawalCredentials(test.address)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.execution, execution)
+			}
+			compounding, err := util.CompoundingWithdrawalCredentials(test.address)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.compounding, compounding)
+			}
+		})
+	}
+}
@@ -22,7 +22,6 @@ import (
 
 	"github.com/pkg/errors"
 	bytesutil "github.com/wealdtech/go-bytesutil"
-	e2types "github.com/wealdtech/go-eth2-types/v2"
 	"golang.org/x/crypto/hkdf"
 )
 
@@ -37,9 +36,10 @@ var r = _bigInt("524358751751261904794477405081859658376905525005276378226036586
 // 48 comes from ceil((1.5 * ceil(log2(r))) / 8).
 const l = 48
 
-// PrivateKeyFromSeedAndPath generates a private key given a seed and a path.
-// Follows ERC-2334.
-func PrivateKeyFromSeedAndPath(seed []byte, path string) (*e2types.BLSPrivateKey, error) {
+// PrivateKeyBytesFromSeedAndPath generates the 32-byte big-endian scalar of a private key given a seed and a path.
+// Follows ERC-2334.  Unlike PrivateKeyFromSeedAndPath it does not require the BLS library, so is available in builds
+// without cgo.
+func PrivateKeyBytesFromSeedAndPath(seed []byte, path string) ([]byte, error) {
 	if path == "" {
 		return nil, errors.New("no path")
 	}
@@ -81,7 +81,7 @@ func PrivateKeyFromSeedAndPath(seed []byte, path string) (*e2types.BLSPrivateKey
 	skBytes := sk.Bytes()
 	copy(bytes[32-len(skBytes):], skBytes)
 
-	return e2types.BLSPrivateKeyFromBytes(bytes)
+	return bytes, nil
 }
 
 // DeriveMasterSK derives the master secret key from a seed.
@@ -91,7 +91,7 @@ func DeriveMasterSK(seed []byte) (*big.Int, error) {
 		return nil, errors.New("seed must be at least 128 bits")
 	}
 
-	return hkdfModR(seed, "")
+	return keyGen(seed, nil, &keyGenParameters{salt: []byte(keyGenSalt), draft: KeyGenDraft4})
 }
 
 // DeriveChildSK derives the child secret key from a parent key.
@@ -102,7 +102,7 @@ func DeriveChildSK(parentSK *big.Int, index uint32) (*big.Int, error) {
 		return nil, err
 	}
 
-	return hkdfModR(pk, "")
+	return keyGen(pk, nil, &keyGenParameters{salt: []byte(keyGenSalt), draft: KeyGenDraft4})
 }
 
 // ikmToLamportSK creates a Lamport secret key.
@@ -150,28 +150,6 @@ func parentSKToLamportPK(parentSK *big.Int, index uint32) ([]byte, error) {
 	return compressedLamportPK, nil
 }
 
-// hkdfModR hashes 32 random bytes into the subgroup of the BLS12-381 private keys.
-func hkdfModR(ikm []byte, keyInfo string) (*big.Int, error) {
-	salt := []byte("BLS-SIG-KEYGEN-SALT-")
-	sk := big.NewInt(0)
-	for sk.Cmp(big.NewInt(0)) == 0 {
-		salt = SHA256(salt)
-		prk := hkdf.Extract(sha256.New, append(ikm, i2OSP(big.NewInt(0), 1)...), salt)
-		okm := hkdf.Expand(sha256.New, prk, append([]byte(keyInfo), i2OSP(big.NewInt(int64(l)), 2)...))
-		okmOut := make([]byte, l)
-		read, err := okm.Read(okmOut)
-		if err != nil {
-			return nil, err
-		}
-		if read != l {
-			return nil, fmt.Errorf("only read %d bytes", read)
-		}
-		sk = new(big.Int).Mod(osToIP(okmOut), r)
-	}
-
-	return sk, nil
-}
-
 // osToIP turns a byte array in to an integer as per https://ietf.org/rfc/rfc3447.txt
 func osToIP(data []byte) *big.Int {
 	return new(big.Int).SetBytes(data)
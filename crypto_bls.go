@@ -0,0 +1,42 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// PrivateKeyFromSeedAndPath generates a private key given a seed and a path.
+// Follows ERC-2334.
+func PrivateKeyFromSeedAndPath(seed []byte, path string) (*e2types.BLSPrivateKey, error) {
+	bytes, err := PrivateKeyBytesFromSeedAndPath(seed, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return e2types.BLSPrivateKeyFromBytes(bytes)
+}
+
+// PublicKeyFromSeedAndPath generates a public key given a seed and a path.
+// Follows ERC-2334.
+func PublicKeyFromSeedAndPath(seed []byte, path string) (e2types.PublicKey, error) {
+	key, err := PrivateKeyFromSeedAndPath(seed, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.PublicKey(), nil
+}
@@ -0,0 +1,205 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// MinDepositAmount is the minimum amount of a deposit, in Gwei.
+const MinDepositAmount = Gwei(1_000_000_000)
+
+// checkDepositAmount checks that the amount of a deposit is at least MinDepositAmount and, for a deposit that creates
+// a validator, no more than the maximum effective balance of a validator with the given withdrawal credentials.
+// A top up adds to the balance of an existing validator, so has no maximum.
+func checkDepositAmount(withdrawalCredentials []byte, amount Gwei, topUp bool) error {
+	if amount < MinDepositAmount {
+		return fmt.Errorf("amount %d below minimum deposit of %d Gwei", amount, MinDepositAmount)
+	}
+	if maxAmount := maxEffectiveBalance(withdrawalCredentials); !topUp && amount > maxAmount {
+		return fmt.Errorf("amount %d above maximum effective balance of %d Gwei", amount, maxAmount)
+	}
+
+	return nil
+}
+
+// DepositMessage is the message signed by a deposit.
+type DepositMessage struct {
+	PublicKey             []byte
+	WithdrawalCredentials []byte
+	Amount                Gwei
+}
+
+// HashTreeRoot returns the hash tree root of the deposit message.
+func (d *DepositMessage) HashTreeRoot() ([]byte, error) {
+	if len(d.PublicKey) != 48 {
+		return nil, errors.New("public key must be 48 bytes")
+	}
+	if len(d.WithdrawalCredentials) != 32 {
+		return nil, errors.New("withdrawal credentials must be 32 bytes")
+	}
+	pubKeyRoot, err := Merkleize(Pack(d.PublicKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain public key root")
+	}
+
+	return Merkleize([][]byte{
+		pubKeyRoot,
+		d.WithdrawalCredentials,
+		uint64Root(uint64(d.Amount)),
+	})
+}
+
+// DepositData is a signed deposit.
+type DepositData struct {
+	PublicKey             []byte
+	WithdrawalCredentials []byte
+	Amount                Gwei
+	Signature             []byte
+}
+
+// Message returns the deposit message signed by the deposit.
+func (d *DepositData) Message() *DepositMessage {
+	return &DepositMessage{
+		PublicKey:             d.PublicKey,
+		WithdrawalCredentials: d.WithdrawalCredentials,
+		Amount:                d.Amount,
+	}
+}
+
+// HashTreeRoot returns the hash tree root of the deposit data.
+func (d *DepositData) HashTreeRoot() ([]byte, error) {
+	if len(d.PublicKey) != 48 {
+		return nil, errors.New("public key must be 48 bytes")
+	}
+	if len(d.WithdrawalCredentials) != 32 {
+		return nil, errors.New("withdrawal credentials must be 32 bytes")
+	}
+	if len(d.Signature) != 96 {
+		return nil, errors.New("signature must be 96 bytes")
+	}
+	pubKeyRoot, err := Merkleize(Pack(d.PublicKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain public key root")
+	}
+	signatureRoot, err := Merkleize(Pack(d.Signature))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain signature root")
+	}
+
+	return Merkleize([][]byte{
+		pubKeyRoot,
+		d.WithdrawalCredentials,
+		uint64Root(uint64(d.Amount)),
+		signatureRoot,
+	})
+}
+
+// DepositSigningRoot returns the signing root of a deposit message.
+// Deposits are signed with the genesis fork version of the network and no genesis validators root, so remain
+// valid on every fork.
+func DepositSigningRoot(message *DepositMessage, genesisForkVersion []byte) ([]byte, error) {
+	if message == nil {
+		return nil, errors.New("no deposit message supplied")
+	}
+	root, err := message.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain deposit message root")
+	}
+
+	return computeSigningRoot(root, domainDeposit, genesisForkVersion, make([]byte, 32))
+}
+
+// NewDepositDataWithBackend creates deposit data for a new validator with the given key from any BLS backend.
+// It is otherwise the same as NewDepositData.
+func NewDepositDataWithBackend(key BLSPrivateKey,
+	withdrawalCredentials []byte,
+	amount Gwei,
+	genesisForkVersion []byte,
+) (
+	*DepositData,
+	error,
+) {
+	return newDepositDataWithBackend(key, withdrawalCredentials, amount, genesisForkVersion, false)
+}
+
+// NewCompoundingDepositDataWithBackend creates deposit data for a new validator with the given key from any BLS
+// backend and compounding withdrawal credentials.  It is otherwise the same as NewCompoundingDepositData.
+func NewCompoundingDepositDataWithBackend(key BLSPrivateKey,
+	address []byte,
+	amount Gwei,
+	genesisForkVersion []byte,
+) (
+	*DepositData,
+	error,
+) {
+	withdrawalCredentials, err := CompoundingWithdrawalCredentials(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDepositDataWithBackend(key, withdrawalCredentials, amount, genesisForkVersion, false)
+}
+
+// NewTopUpDepositDataWithBackend creates deposit data to top up the balance of the existing validator with the given
+// key from any BLS backend.  It is otherwise the same as NewTopUpDepositData.
+func NewTopUpDepositDataWithBackend(key BLSPrivateKey,
+	withdrawalCredentials []byte,
+	amount Gwei,
+	genesisForkVersion []byte,
+) (
+	*DepositData,
+	error,
+) {
+	return newDepositDataWithBackend(key, withdrawalCredentials, amount, genesisForkVersion, true)
+}
+
+// newDepositDataWithBackend creates signed deposit data.
+func newDepositDataWithBackend(key BLSPrivateKey,
+	withdrawalCredentials []byte,
+	amount Gwei,
+	genesisForkVersion []byte,
+	topUp bool,
+) (
+	*DepositData,
+	error,
+) {
+	if key == nil {
+		return nil, errors.New("no key supplied")
+	}
+	message := &DepositMessage{
+		PublicKey:             key.PublicKey().Marshal(),
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                amount,
+	}
+	signingRoot, err := DepositSigningRoot(message, genesisForkVersion)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWithdrawalCredentials(withdrawalCredentials); err != nil {
+		return nil, err
+	}
+	if err := checkDepositAmount(withdrawalCredentials, amount, topUp); err != nil {
+		return nil, err
+	}
+
+	return &DepositData{
+		PublicKey:             message.PublicKey,
+		WithdrawalCredentials: message.WithdrawalCredentials,
+		Amount:                message.Amount,
+		Signature:             key.Sign(signingRoot).Marshal(),
+	}, nil
+}
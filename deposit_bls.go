@@ -0,0 +1,87 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// NewDepositData creates deposit data for a new validator with the given key, signed for the network with the given
+// genesis fork version.
+// The amount must be at least MinDepositAmount and no more than the maximum effective balance of a
+// validator with the given withdrawal credentials.
+func NewDepositData(key *e2types.BLSPrivateKey,
+	withdrawalCredentials []byte,
+	amount Gwei,
+	genesisForkVersion []byte,
+) (
+	*DepositData,
+	error,
+) {
+	return newDepositData(key, withdrawalCredentials, amount, genesisForkVersion, false)
+}
+
+// NewCompoundingDepositData creates deposit data for a new validator with the given key and compounding withdrawal
+// credentials for the given execution address, signed for the network with the given genesis fork version.
+// The amount must be at least MinDepositAmount and no more than MaxEffectiveBalanceElectra.
+func NewCompoundingDepositData(key *e2types.BLSPrivateKey,
+	address []byte,
+	amount Gwei,
+	genesisForkVersion []byte,
+) (
+	*DepositData,
+	error,
+) {
+	withdrawalCredentials, err := CompoundingWithdrawalCredentials(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDepositData(key, withdrawalCredentials, amount, genesisForkVersion, false)
+}
+
+// NewTopUpDepositData creates deposit data to top up the balance of the existing validator with the given key,
+// signed for the network with the given genesis fork version.
+// The withdrawal credentials should be those of the validator; they are not changed by the deposit.
+// The amount must be at least MinDepositAmount.
+func NewTopUpDepositData(key *e2types.BLSPrivateKey,
+	withdrawalCredentials []byte,
+	amount Gwei,
+	genesisForkVersion []byte,
+) (
+	*DepositData,
+	error,
+) {
+	return newDepositData(key, withdrawalCredentials, amount, genesisForkVersion, true)
+}
+
+// newDepositData creates signed deposit data.
+func newDepositData(key *e2types.BLSPrivateKey,
+	withdrawalCredentials []byte,
+	amount Gwei,
+	genesisForkVersion []byte,
+	topUp bool,
+) (
+	*DepositData,
+	error,
+) {
+	if key == nil {
+		return nil, errors.New("no key supplied")
+	}
+
+	return newDepositDataWithBackend(HerumiPrivateKey(key), withdrawalCredentials, amount, genesisForkVersion, topUp)
+}
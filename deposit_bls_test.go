@@ -0,0 +1,202 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestNewDepositData(t *testing.T) {
+	key, err := util.PrivateKeyFromSeedAndPath(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	withdrawalCredentials, err := util.BLSWithdrawalCredentials(key.PublicKey().Marshal())
+	require.NoError(t, err)
+	genesisForkVersion := []byte{0x00, 0x00, 0x00, 0x00}
+
+	_, err = util.NewDepositData(nil, withdrawalCredentials, util.Gwei(util.MaxEffectiveBalance), genesisForkVersion)
+	require.EqualError(t, err, "no key supplied")
+	_, err = util.NewDepositData(key, []byte{0x01}, util.Gwei(util.MaxEffectiveBalance), genesisForkVersion)
+	require.EqualError(t, err, "failed to obtain deposit message root: withdrawal credentials must be 32 bytes")
+	_, err = util.DepositSigningRoot(nil, genesisForkVersion)
+	require.EqualError(t, err, "no deposit message supplied")
+
+	data, err := util.NewDepositData(key, withdrawalCredentials, util.Gwei(util.MaxEffectiveBalance), genesisForkVersion)
+	require.NoError(t, err)
+	signingRoot, err := util.DepositSigningRoot(data.Message(), genesisForkVersion)
+	require.NoError(t, err)
+	signature, err := e2types.BLSSignatureFromBytes(data.Signature)
+	require.NoError(t, err)
+	assert.True(t, signature.Verify(signingRoot, key.PublicKey()))
+
+	// The signature is not valid for another network.
+	signingRoot, err = util.DepositSigningRoot(data.Message(), []byte{0x00, 0x00, 0x10, 0x20})
+	require.NoError(t, err)
+	assert.False(t, signature.Verify(signingRoot, key.PublicKey()))
+}
+
+func TestNewDepositDataAmounts(t *testing.T) {
+	key, err := util.PrivateKeyFromSeedAndPath(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	blsCredentials, err := util.BLSWithdrawalCredentials(key.PublicKey().Marshal())
+	require.NoError(t, err)
+	address := _byteArray("0102030405060708090a0b0c0d0e0f1011121314")
+	compoundingCredentials, err := util.CompoundingWithdrawalCredentials(address)
+	require.NoError(t, err)
+	genesisForkVersion := []byte{0x00, 0x00, 0x00, 0x00}
+
+	tests := []struct {
+		name        string
+		create      func() (*util.DepositData, error)
+		err         string
+		credentials []byte
+		amount      util.Gwei
+	}{
+		{
+			name: "BelowMinimum",
+			create: func() (*util.DepositData, error) {
+				return util.NewDepositData(key, blsCredentials, util.MinDepositAmount-1, genesisForkVersion)
+			},
+			err: "amount 999999999 below minimum deposit of 1000000000 Gwei",
+		},
+		{
+			name: "Minimum",
+			create: func() (*util.DepositData, error) {
+				return util.NewDepositData(key, blsCredentials, util.MinDepositAmount, genesisForkVersion)
+			},
+			credentials: blsCredentials,
+			amount:      util.MinDepositAmount,
+		},
+		{
+			name: "AboveMaximum",
+			create: func() (*util.DepositData, error) {
+				return util.NewDepositData(key, blsCredentials, util.Gwei(util.MaxEffectiveBalance)+1, genesisForkVersion)
+			},
+			err: "amount 32000000001 above maximum effective balance of 32000000000 Gwei",
+		},
+		{
+			name: "PrefixUnknown",
+			create: func() (*util.DepositData, error) {
+				return util.NewDepositData(key, append([]byte{0x03}, blsCredentials[1:]...), util.Gwei(util.MaxEffectiveBalance), genesisForkVersion)
+			},
+			err: "unknown withdrawal credentials prefix 0x03",
+		},
+		{
+			name: "CompoundingAddressInvalid",
+			create: func() (*util.DepositData, error) {
+				return util.NewCompoundingDepositData(key, address[:19], util.Gwei(util.MaxEffectiveBalance), genesisForkVersion)
+			},
+			err: "execution address must be 20 bytes",
+		},
+		{
+			name: "Compounding",
+			create: func() (*util.DepositData, error) {
+				return util.NewCompoundingDepositData(key, address, 1_234_567_890_123, genesisForkVersion)
+			},
+			credentials: compoundingCredentials,
+			amount:      1_234_567_890_123,
+		},
+		{
+			name: "CompoundingAboveMaximum",
+			create: func() (*util.DepositData, error) {
+				return util.NewCompoundingDepositData(key, address, util.Gwei(util.MaxEffectiveBalanceElectra)+1, genesisForkVersion)
+			},
+			err: "amount 2048000000001 above maximum effective balance of 2048000000000 Gwei",
+		},
+		{
+			name: "TopUpBelowMinimum",
+			create: func() (*util.DepositData, error) {
+				return util.NewTopUpDepositData(key, blsCredentials, util.MinDepositAmount-1, genesisForkVersion)
+			},
+			err: "amount 999999999 below minimum deposit of 1000000000 Gwei",
+		},
+		{
+			name: "TopUp",
+			create: func() (*util.DepositData, error) {
+				return util.NewTopUpDepositData(key, blsCredentials, 1_500_000_000, genesisForkVersion)
+			},
+			credentials: blsCredentials,
+			amount:      1_500_000_000,
+		},
+		{
+			name: "TopUpAboveMaximum",
+			create: func() (*util.DepositData, error) {
+				return util.NewTopUpDepositData(key, compoundingCredentials, util.Gwei(util.MaxEffectiveBalanceElectra)+1, genesisForkVersion)
+			},
+			credentials: compoundingCredentials,
+			amount:      util.Gwei(util.MaxEffectiveBalanceElectra) + 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := test.create()
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, key.PublicKey().Marshal(), data.PublicKey)
+			assert.Equal(t, test.credentials, data.WithdrawalCredentials)
+			assert.Equal(t, test.amount, data.Amount)
+			signingRoot, err := util.DepositSigningRoot(data.Message(), genesisForkVersion)
+			require.NoError(t, err)
+			signature, err := e2types.BLSSignatureFromBytes(data.Signature)
+			require.NoError(t, err)
+			assert.True(t, signature.Verify(signingRoot, key.PublicKey()))
+		})
+	}
+}
+
+// TestNewDepositDataWithBackend checks that deposits created with a key from another backend match those created
+// with the default backend.
+func TestNewDepositDataWithBackend(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	key, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	referenceKey, err := util.PrivateKeyFromSeedAndPathWithBackend(util.ReferenceBLSBackend(), seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	withdrawalCredentials, err := util.BLSWithdrawalCredentials(key.PublicKey().Marshal())
+	require.NoError(t, err)
+	address := _byteArray("0102030405060708090a0b0c0d0e0f1011121314")
+	genesisForkVersion := []byte{0x00, 0x00, 0x10, 0x20}
+
+	_, err = util.NewDepositDataWithBackend(nil, withdrawalCredentials, util.MinDepositAmount, genesisForkVersion)
+	require.EqualError(t, err, "no key supplied")
+
+	expected, err := util.NewDepositData(key, withdrawalCredentials, util.MinDepositAmount, genesisForkVersion)
+	require.NoError(t, err)
+	data, err := util.NewDepositDataWithBackend(referenceKey, withdrawalCredentials, util.MinDepositAmount, genesisForkVersion)
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+
+	expected, err = util.NewCompoundingDepositData(key, address, util.Gwei(util.MaxEffectiveBalanceElectra), genesisForkVersion)
+	require.NoError(t, err)
+	data, err = util.NewCompoundingDepositDataWithBackend(referenceKey, address, util.Gwei(util.MaxEffectiveBalanceElectra), genesisForkVersion)
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+
+	expected, err = util.NewTopUpDepositData(key, withdrawalCredentials, util.Gwei(util.MaxEffectiveBalance)+1, genesisForkVersion)
+	require.NoError(t, err)
+	data, err = util.NewTopUpDepositDataWithBackend(referenceKey, withdrawalCredentials, util.Gwei(util.MaxEffectiveBalance)+1, genesisForkVersion)
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+}
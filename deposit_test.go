@@ -0,0 +1,83 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestDepositRoots(t *testing.T) {
+	pubKey := bytes.Repeat([]byte{0x01}, 48)
+	withdrawalCredentials := bytes.Repeat([]byte{0x02}, 32)
+	signature := bytes.Repeat([]byte{0x03}, 96)
+	amount := make([]byte, 32)
+	binary.LittleEndian.PutUint64(amount, 32000000000)
+	zero := make([]byte, 32)
+
+	// Roots calculated by hand from the SSZ merkleization rules.
+	pubKeyRoot := util.SHA256(pubKey[:32], append(append([]byte{}, pubKey[32:]...), make([]byte, 16)...))
+	signatureRoot := util.SHA256(util.SHA256(signature[:32], signature[32:64]), util.SHA256(signature[64:], zero))
+	messageRoot := util.SHA256(util.SHA256(pubKeyRoot, withdrawalCredentials), util.SHA256(amount, zero))
+	dataRoot := util.SHA256(util.SHA256(pubKeyRoot, withdrawalCredentials), util.SHA256(amount, signatureRoot))
+
+	data := &util.DepositData{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                32000000000,
+		Signature:             signature,
+	}
+	root, err := data.Message().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, messageRoot, root)
+	root, err = data.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, dataRoot, root)
+}
+
+func TestDepositRootErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data *util.DepositData
+		err  string
+	}{
+		{
+			name: "PublicKeyInvalid",
+			data: &util.DepositData{PublicKey: []byte{0x01}, WithdrawalCredentials: make([]byte, 32), Signature: make([]byte, 96)},
+			err:  "public key must be 48 bytes",
+		},
+		{
+			name: "WithdrawalCredentialsInvalid",
+			data: &util.DepositData{PublicKey: make([]byte, 48), WithdrawalCredentials: []byte{0x01}, Signature: make([]byte, 96)},
+			err:  "withdrawal credentials must be 32 bytes",
+		},
+		{
+			name: "SignatureInvalid",
+			data: &util.DepositData{PublicKey: make([]byte, 48), WithdrawalCredentials: make([]byte, 32), Signature: []byte{0x01}},
+			err:  "signature must be 96 bytes",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.data.HashTreeRoot()
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// depositDataJSON is an entry of a deposit data file, as written by the staking deposit CLI.
+type depositDataJSON struct {
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                Gwei   `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ForkVersion           string `json:"fork_version"`
+}
+
+// DepositVerification is the result of verifying a deposit.
+type DepositVerification struct {
+	// Index is the index of the deposit in the deposit data.
+	Index int
+	// PublicKey is the public key of the deposit, or nil if it could not be decoded.
+	PublicKey []byte
+	// Problems are the problems found with the deposit.
+	Problems []string
+}
+
+// Valid returns true if no problems were found with the deposit.
+func (v *DepositVerification) Valid() bool {
+	return len(v.Problems) == 0
+}
+
+// VerifyDepositDataJSON verifies the deposits in a deposit data file, as written by the staking deposit CLI, for
+// the network with the given genesis fork version.
+// Each deposit is checked with VerifyDeposit, its deposit message and deposit data roots are recomputed and compared
+// with those in the file, and deposits for the same public key as an earlier deposit are flagged.
+// An error is returned only if the file cannot be parsed; problems with individual deposits are reported in the
+// results, which are in the same order as the deposits.
+func VerifyDepositDataJSON(data []byte, genesisForkVersion []byte) ([]*DepositVerification, error) {
+	if len(genesisForkVersion) != 4 {
+		return nil, errors.New("genesis fork version must be 4 bytes")
+	}
+	var records []*depositDataJSON
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, errors.Wrap(err, "invalid deposit data")
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no deposits in deposit data")
+	}
+
+	res := make([]*DepositVerification, len(records))
+	seen := make(map[string]int)
+	for i, record := range records {
+		res[i] = &DepositVerification{Index: i}
+		if record == nil {
+			res[i].Problems = append(res[i].Problems, "no deposit")
+
+			continue
+		}
+		res[i].Problems = verifyDepositDataRecord(record, genesisForkVersion)
+		pubKey, err := decodeDepositField(record.PublicKey, 48)
+		if err != nil {
+			continue
+		}
+		res[i].PublicKey = pubKey
+		if first, exists := seen[string(pubKey)]; exists {
+			res[i].Problems = append(res[i].Problems, fmt.Sprintf("duplicate public key, also in deposit %d", first))
+		} else {
+			seen[string(pubKey)] = i
+		}
+	}
+
+	return res, nil
+}
+
+// verifyDepositDataRecord returns the problems found with an entry of a deposit data file.
+func verifyDepositDataRecord(record *depositDataJSON, genesisForkVersion []byte) []string {
+	problems := make([]string, 0)
+	deposit := &DepositData{Amount: record.Amount}
+	var err error
+	if deposit.PublicKey, err = decodeDepositField(record.PublicKey, 48); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid public key: %v", err))
+	}
+	if deposit.WithdrawalCredentials, err = decodeDepositField(record.WithdrawalCredentials, 32); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid withdrawal credentials: %v", err))
+	}
+	if deposit.Signature, err = decodeDepositField(record.Signature, 96); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid signature: %v", err))
+	}
+	if record.ForkVersion != "" {
+		forkVersion, err := decodeDepositField(record.ForkVersion, 4)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("invalid fork version: %v", err))
+		case !bytes.Equal(forkVersion, genesisForkVersion):
+			problems = append(problems, fmt.Sprintf("fork version %#x does not match genesis fork version %#x", forkVersion, genesisForkVersion))
+		}
+	}
+	if len(problems) > 0 {
+		// The deposit cannot be checked further without its fields.
+		return problems
+	}
+
+	problems = append(problems, VerifyDeposit(deposit, genesisForkVersion)...)
+
+	messageRoot, err := deposit.Message().HashTreeRoot()
+	if err != nil {
+		return append(problems, fmt.Sprintf("failed to obtain deposit message root: %v", err))
+	}
+	problems = append(problems, compareDepositRoot("deposit message root", record.DepositMessageRoot, messageRoot)...)
+	dataRoot, err := deposit.HashTreeRoot()
+	if err != nil {
+		return append(problems, fmt.Sprintf("failed to obtain deposit data root: %v", err))
+	}
+	problems = append(problems, compareDepositRoot("deposit data root", record.DepositDataRoot, dataRoot)...)
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return problems
+}
+
+// compareDepositRoot returns a problem if a root in a deposit data file does not match the computed root.
+func compareDepositRoot(name string, input string, root []byte) []string {
+	if input == "" {
+		return []string{"no " + name}
+	}
+	data, err := decodeDepositField(input, 32)
+	if err != nil {
+		return []string{fmt.Sprintf("invalid %s: %v", name, err)}
+	}
+	if !bytes.Equal(data, root) {
+		return []string{fmt.Sprintf("%s %#x does not match computed %#x", name, data, root)}
+	}
+
+	return nil
+}
+
+// decodeDepositField decodes a hex field of a deposit data file, with or without a 0x prefix.
+func decodeDepositField(input string, length int) ([]byte, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.New("invalid hex")
+	}
+	if len(data) != length {
+		return nil, fmt.Errorf("must be %d bytes", length)
+	}
+
+	return data, nil
+}
+
+// VerifyDeposit verifies a deposit that creates a validator for the network with the given genesis fork version,
+// returning the problems found.
+// The deposit's withdrawal credentials must have a known prefix, its amount must be at least MinDepositAmount and
+// no more than the maximum effective balance of a validator with its withdrawal credentials, and its signature must
+// verify against its public key.
+func VerifyDeposit(deposit *DepositData, genesisForkVersion []byte) []string {
+	return verifyDeposit(deposit, genesisForkVersion, false)
+}
+
+// VerifyTopUpDeposit verifies a deposit that tops up the balance of an existing validator for the network with the
+// given genesis fork version, returning the problems found.
+// It is the same as VerifyDeposit, except that the amount has no maximum.
+func VerifyTopUpDeposit(deposit *DepositData, genesisForkVersion []byte) []string {
+	return verifyDeposit(deposit, genesisForkVersion, true)
+}
+
+// verifyDeposit verifies a deposit, returning the problems found.
+func verifyDeposit(deposit *DepositData, genesisForkVersion []byte, topUp bool) []string {
+	if deposit == nil {
+		return []string{"no deposit supplied"}
+	}
+	problems := make([]string, 0)
+	if len(deposit.PublicKey) != 48 {
+		problems = append(problems, "public key must be 48 bytes")
+	}
+	if len(deposit.WithdrawalCredentials) != 32 {
+		problems = append(problems, "withdrawal credentials must be 32 bytes")
+	}
+	if len(deposit.Signature) != 96 {
+		problems = append(problems, "signature must be 96 bytes")
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+
+	if err := checkWithdrawalCredentials(deposit.WithdrawalCredentials); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if err := checkDepositAmount(deposit.WithdrawalCredentials, deposit.Amount, topUp); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if problem := verifyDepositSignature(deposit, genesisForkVersion); problem != "" {
+		problems = append(problems, problem)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return problems
+}
+
+// verifyDepositSignature returns a problem if the signature of a deposit does not verify.
+func verifyDepositSignature(deposit *DepositData, genesisForkVersion []byte) string {
+	signingRoot, err := DepositSigningRoot(deposit.Message(), genesisForkVersion)
+	if err != nil {
+		return fmt.Sprintf("failed to obtain signing root: %v", err)
+	}
+	pubKey, err := e2types.BLSPublicKeyFromBytes(deposit.PublicKey)
+	if err != nil {
+		return fmt.Sprintf("invalid public key: %v", err)
+	}
+	signature, err := e2types.BLSSignatureFromBytes(deposit.Signature)
+	if err != nil {
+		return fmt.Sprintf("invalid signature: %v", err)
+	}
+	if !signature.Verify(signingRoot, pubKey) {
+		return "signature does not verify"
+	}
+
+	return ""
+}
@@ -0,0 +1,306 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+// depositRecord creates an entry of a deposit data file for a deposit.
+func depositRecord(t *testing.T, deposit *util.DepositData, forkVersion []byte) map[string]any {
+	t.Helper()
+
+	messageRoot, err := deposit.Message().HashTreeRoot()
+	require.NoError(t, err)
+	dataRoot, err := deposit.HashTreeRoot()
+	require.NoError(t, err)
+
+	return map[string]any{
+		"pubkey":                 hex.EncodeToString(deposit.PublicKey),
+		"withdrawal_credentials": hex.EncodeToString(deposit.WithdrawalCredentials),
+		"amount":                 deposit.Amount,
+		"signature":              hex.EncodeToString(deposit.Signature),
+		"deposit_message_root":   hex.EncodeToString(messageRoot),
+		"deposit_data_root":      hex.EncodeToString(dataRoot),
+		"fork_version":           hex.EncodeToString(forkVersion),
+		"network_name":           "mainnet",
+		"deposit_cli_version":    "2.7.0",
+	}
+}
+
+func TestVerifyDepositDataJSON(t *testing.T) {
+	genesisForkVersion := []byte{0x00, 0x00, 0x00, 0x00}
+	deposits, err := util.InteropDepositData(0, 3, genesisForkVersion)
+	require.NoError(t, err)
+	otherNetworkDeposits, err := util.InteropDepositData(0, 1, []byte{0x10, 0x00, 0x00, 0x38})
+	require.NoError(t, err)
+
+	good := make([]map[string]any, len(deposits))
+	for i := range deposits {
+		good[i] = depositRecord(t, deposits[i], genesisForkVersion)
+	}
+	modified := func(index int, field string, value any) map[string]any {
+		res := make(map[string]any)
+		for k, v := range good[index] {
+			res[k] = v
+		}
+		if value == nil {
+			delete(res, field)
+		} else {
+			res[field] = value
+		}
+
+		return res
+	}
+
+	tests := []struct {
+		name               string
+		genesisForkVersion []byte
+		records            any
+		err                string
+		problems           [][]string
+	}{
+		{
+			name:               "GenesisForkVersionInvalid",
+			genesisForkVersion: []byte{0x00},
+			records:            good,
+			err:                "genesis fork version must be 4 bytes",
+		},
+		{
+			name:               "NotArray",
+			genesisForkVersion: genesisForkVersion,
+			records:            good[0],
+			err:                "invalid deposit data: json: cannot unmarshal object into Go value of type []*util.depositDataJSON",
+		},
+		{
+			name:               "Empty",
+			genesisForkVersion: genesisForkVersion,
+			records:            []map[string]any{},
+			err:                "no deposits in deposit data",
+		},
+		{
+			name:               "Good",
+			genesisForkVersion: genesisForkVersion,
+			records:            good,
+			problems:           [][]string{nil, nil, nil},
+		},
+		{
+			name:               "Null",
+			genesisForkVersion: genesisForkVersion,
+			records:            []map[string]any{nil},
+			problems:           [][]string{{"no deposit"}},
+		},
+		{
+			name:               "Duplicate",
+			genesisForkVersion: genesisForkVersion,
+			records:            []map[string]any{good[0], good[1], good[0]},
+			problems:           [][]string{nil, nil, {"duplicate public key, also in deposit 0"}},
+		},
+		{
+			name:               "WrongNetwork",
+			genesisForkVersion: genesisForkVersion,
+			records:            []map[string]any{depositRecord(t, otherNetworkDeposits[0], []byte{0x10, 0x00, 0x00, 0x38})},
+			problems:           [][]string{{"fork version 0x10000038 does not match genesis fork version 0x00000000"}},
+		},
+		{
+			name:               "SignedForWrongNetwork",
+			genesisForkVersion: genesisForkVersion,
+			records:            []map[string]any{depositRecord(t, otherNetworkDeposits[0], genesisForkVersion)},
+			problems:           [][]string{{"signature does not verify"}},
+		},
+		{
+			name:               "FieldsInvalid",
+			genesisForkVersion: genesisForkVersion,
+			records: []map[string]any{
+				modified(0, "pubkey", "0x01"),
+				modified(1, "withdrawal_credentials", "xyz"),
+				modified(2, "signature", nil),
+			},
+			problems: [][]string{
+				{"invalid public key: must be 48 bytes"},
+				{"invalid withdrawal credentials: invalid hex"},
+				{"invalid signature: must be 96 bytes"},
+			},
+		},
+		{
+			name:               "AmountModified",
+			genesisForkVersion: genesisForkVersion,
+			records:            []map[string]any{modified(0, "amount", uint64(31_000_000_000))},
+			problems: [][]string{{
+				"signature does not verify",
+				fmt.Sprintf("deposit message root 0x%s does not match computed", good[0]["deposit_message_root"]),
+				fmt.Sprintf("deposit data root 0x%s does not match computed", good[0]["deposit_data_root"]),
+			}},
+		},
+		{
+			name:               "RootsMissing",
+			genesisForkVersion: genesisForkVersion,
+			records:            []map[string]any{modified(0, "deposit_message_root", nil)},
+			problems:           [][]string{{"no deposit message root"}},
+		},
+		{
+			name:               "RootInvalid",
+			genesisForkVersion: genesisForkVersion,
+			records:            []map[string]any{modified(0, "deposit_data_root", "0x0102")},
+			problems:           [][]string{{"invalid deposit data root: must be 32 bytes"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.records)
+			require.NoError(t, err)
+			res, err := util.VerifyDepositDataJSON(data, test.genesisForkVersion)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res, len(test.problems))
+			for i := range res {
+				assert.Equal(t, i, res[i].Index)
+				assert.Equal(t, len(test.problems[i]) == 0, res[i].Valid())
+				require.Len(t, res[i].Problems, len(test.problems[i]))
+				for j := range test.problems[i] {
+					assert.Contains(t, res[i].Problems[j], test.problems[i][j])
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyDeposit(t *testing.T) {
+	genesisForkVersion := []byte{0x00, 0x00, 0x00, 0x00}
+	key, err := util.PrivateKeyFromSeedAndPath(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	// Deposits are signed directly, as NewDepositData does not create invalid deposits.
+	deposit := func(withdrawalCredentials string, amount util.Gwei) *util.DepositData {
+		res := &util.DepositData{
+			PublicKey:             key.PublicKey().Marshal(),
+			WithdrawalCredentials: _byteArray(withdrawalCredentials),
+			Amount:                amount,
+		}
+		signingRoot, err := util.DepositSigningRoot(res.Message(), genesisForkVersion)
+		require.NoError(t, err)
+		res.Signature = key.Sign(signingRoot).Marshal()
+
+		return res
+	}
+
+	tests := []struct {
+		name     string
+		deposit  *util.DepositData
+		problems []string
+	}{
+		{
+			name:     "Nil",
+			problems: []string{"no deposit supplied"},
+		},
+		{
+			name:     "FieldsInvalid",
+			deposit:  &util.DepositData{},
+			problems: []string{"public key must be 48 bytes", "withdrawal credentials must be 32 bytes", "signature must be 96 bytes"},
+		},
+		{
+			name:    "BLS",
+			deposit: deposit("00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b", util.Gwei(util.MaxEffectiveBalance)),
+		},
+		{
+			name:     "BLSAboveMaximum",
+			deposit:  deposit("00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b", util.Gwei(util.MaxEffectiveBalance)+1),
+			problems: []string{"amount 32000000001 above maximum effective balance of 32000000000 Gwei"},
+		},
+		{
+			name:     "BelowMinimum",
+			deposit:  deposit("00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b", util.MinDepositAmount-1),
+			problems: []string{"amount 999999999 below minimum deposit of 1000000000 Gwei"},
+		},
+		{
+			name:    "Execution",
+			deposit: deposit("0100000000000000000000000102030405060708090a0b0c0d0e0f1011121314", util.Gwei(util.MaxEffectiveBalance)),
+		},
+		{
+			name:     "ExecutionPaddingInvalid",
+			deposit:  deposit("0100000000000000000000010102030405060708090a0b0c0d0e0f1011121314", util.Gwei(util.MaxEffectiveBalance)),
+			problems: []string{"execution withdrawal credentials must have 11 zero bytes after the prefix"},
+		},
+		{
+			name:    "Compounding",
+			deposit: deposit("0200000000000000000000000102030405060708090a0b0c0d0e0f1011121314", util.Gwei(util.MaxEffectiveBalanceElectra)),
+		},
+		{
+			name:     "CompoundingAboveMaximum",
+			deposit:  deposit("0200000000000000000000000102030405060708090a0b0c0d0e0f1011121314", util.Gwei(util.MaxEffectiveBalanceElectra)+1),
+			problems: []string{"amount 2048000000001 above maximum effective balance of 2048000000000 Gwei"},
+		},
+		{
+			name:     "PrefixUnknown",
+			deposit:  deposit("0300000000000000000000000102030405060708090a0b0c0d0e0f1011121314", util.Gwei(util.MaxEffectiveBalance)),
+			problems: []string{"unknown withdrawal credentials prefix 0x03"},
+		},
+		{
+			name: "SignatureInvalid",
+			deposit: &util.DepositData{
+				PublicKey:             key.PublicKey().Marshal(),
+				WithdrawalCredentials: _byteArray("00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+				Amount:                util.Gwei(util.MaxEffectiveBalance),
+				Signature:             deposit("00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b", util.MinDepositAmount).Signature,
+			},
+			problems: []string{"signature does not verify"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.problems, util.VerifyDeposit(test.deposit, genesisForkVersion))
+		})
+	}
+}
+
+func TestVerifyTopUpDeposit(t *testing.T) {
+	genesisForkVersion := []byte{0x00, 0x00, 0x10, 0x20}
+	key, err := util.PrivateKeyFromSeedAndPath(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	withdrawalCredentials, err := util.BLSWithdrawalCredentials(key.PublicKey().Marshal())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"no deposit supplied"}, util.VerifyTopUpDeposit(nil, genesisForkVersion))
+
+	// A top up can take the balance of a validator above its maximum effective balance.
+	deposit, err := util.NewTopUpDepositData(key, withdrawalCredentials, util.Gwei(util.MaxEffectiveBalance)+1, genesisForkVersion)
+	require.NoError(t, err)
+	assert.Empty(t, util.VerifyTopUpDeposit(deposit, genesisForkVersion))
+	assert.Equal(t, []string{"amount 32000000001 above maximum effective balance of 32000000000 Gwei"},
+		util.VerifyDeposit(deposit, genesisForkVersion))
+
+	// The minimum still applies.
+	deposit.Amount = util.MinDepositAmount - 1
+	assert.Equal(t, []string{"amount 999999999 below minimum deposit of 1000000000 Gwei", "signature does not verify"},
+		util.VerifyTopUpDeposit(deposit, genesisForkVersion))
+
+	// The signature must be for the network.
+	deposit, err = util.NewTopUpDepositData(key, withdrawalCredentials, util.MinDepositAmount, genesisForkVersion)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"signature does not verify"}, util.VerifyTopUpDeposit(deposit, []byte{0x00, 0x00, 0x00, 0x00}))
+}
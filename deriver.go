@@ -0,0 +1,174 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// DerivedKey is a private key and the path at which it was derived.
+type DerivedKey struct {
+	Path       string
+	PrivateKey *e2types.BLSPrivateKey
+}
+
+// maxKeyDeriverCacheEntries is the maximum number of intermediate keys held by a key deriver.
+const maxKeyDeriverCacheEntries = 1024
+
+// KeyDeriver derives keys from a seed following ERC-2334.
+// Intermediate keys are cached, so keys that share path prefixes are derived without repeating work.  The keys
+// requested are not cached, and the cache is cleared when it fills, so memory use is bounded however many keys are
+// derived.
+// It is safe for concurrent use.
+type KeyDeriver struct {
+	seed  []byte
+	mutex sync.RWMutex
+	cache map[string]*big.Int
+}
+
+// NewKeyDeriver creates a key deriver for a seed.
+func NewKeyDeriver(seed []byte) (*KeyDeriver, error) {
+	if len(seed) < 16 {
+		return nil, errors.New("seed must be at least 128 bits")
+	}
+
+	return &KeyDeriver{
+		seed:  seed,
+		cache: make(map[string]*big.Int),
+	}, nil
+}
+
+// PrivateKey derives the private key at a path.
+func (d *KeyDeriver) PrivateKey(path string) (*e2types.BLSPrivateKey, error) {
+	if strings.Contains(path, "{") {
+		return nil, errors.New("path template supplied where path expected")
+	}
+	if _, err := NewPathIterator(path); err != nil {
+		return nil, err
+	}
+	sk, err := d.secretKey(path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return e2types.BLSPrivateKeyFromBytes(i2OSP(sk, 32))
+}
+
+// PublicKey derives the public key at a path.
+func (d *KeyDeriver) PublicKey(path string) (e2types.PublicKey, error) {
+	key, err := d.PrivateKey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.PublicKey(), nil
+}
+
+// PrivateKeys derives the private keys at all of the paths of a path template.
+func (d *KeyDeriver) PrivateKeys(template string) ([]*DerivedKey, error) {
+	paths, err := ExpandPathTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*DerivedKey, len(paths))
+	for i, path := range paths {
+		sk, err := d.secretKey(path, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to derive key at %s", path)
+		}
+		key, err := e2types.BLSPrivateKeyFromBytes(i2OSP(sk, 32))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create key at %s", path)
+		}
+		res[i] = &DerivedKey{
+			Path:       path,
+			PrivateKey: key,
+		}
+	}
+
+	return res, nil
+}
+
+// secretKey derives the secret key at a validated path, using the cache.
+// Only intermediate keys are added to the cache.
+func (d *KeyDeriver) secretKey(path string, intermediate bool) (*big.Int, error) {
+	d.mutex.RLock()
+	sk, exists := d.cache[path]
+	d.mutex.RUnlock()
+	if exists {
+		return sk, nil
+	}
+
+	var err error
+	parentPath, indexStr, isChild := cutLast(path, "/")
+	if isChild {
+		parentSK, err := d.secretKey(parentPath, true)
+		if err != nil {
+			return nil, err
+		}
+		index, err := strconv.ParseUint(indexStr, 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid index %q", indexStr)
+		}
+		sk, err = DeriveChildSK(parentSK, uint32(index))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to derive child SK at %s", path)
+		}
+	} else {
+		sk, err = DeriveMasterSK(d.seed)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate master key")
+		}
+	}
+
+	if intermediate {
+		d.mutex.Lock()
+		if len(d.cache) >= maxKeyDeriverCacheEntries {
+			d.cache = make(map[string]*big.Int)
+		}
+		d.cache[path] = sk
+		d.mutex.Unlock()
+	}
+
+	return sk, nil
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s string, sep string) (string, string, bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+
+	return s, "", false
+}
+
+// PrivateKeysFromSeedAndPathTemplate generates the private keys at all of the paths of a path template.
+// Follows ERC-2334.
+func PrivateKeysFromSeedAndPathTemplate(seed []byte, template string) ([]*DerivedKey, error) {
+	deriver, err := NewKeyDeriver(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return deriver.PrivateKeys(template)
+}
@@ -0,0 +1,44 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyDeriverCache(t *testing.T) {
+	deriver, err := NewKeyDeriver(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"))
+	require.NoError(t, err)
+
+	// Only the intermediate keys are cached.
+	_, err = deriver.PrivateKey("m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	assert.Len(t, deriver.cache, 5)
+	assert.Contains(t, deriver.cache, "m/12381/3600/0/0")
+	assert.NotContains(t, deriver.cache, "m/12381/3600/0/0/0")
+
+	// The cache does not grow beyond its limit.
+	keys, err := deriver.PrivateKeys("m/12381/3600/{0..599}/0/0")
+	require.NoError(t, err)
+	require.Len(t, keys, 600)
+	assert.LessOrEqual(t, len(deriver.cache), maxKeyDeriverCacheEntries)
+	expected, err := PrivateKeyFromSeedAndPath(deriver.seed, "m/12381/3600/599/0/0")
+	require.NoError(t, err)
+	assert.Equal(t, expected.Marshal(), keys[599].PrivateKey.Marshal())
+}
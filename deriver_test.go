@@ -0,0 +1,73 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestKeyDeriver(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+
+	_, err := util.NewKeyDeriver(seed[:15])
+	require.EqualError(t, err, "seed must be at least 128 bits")
+
+	deriver, err := util.NewKeyDeriver(seed)
+	require.NoError(t, err)
+
+	_, err = deriver.PrivateKey("m/12381/{0..1}")
+	require.EqualError(t, err, "path template supplied where path expected")
+	_, err = deriver.PrivateKey("m/12381//0")
+	require.EqualError(t, err, "no entry at path component 2")
+
+	for _, path := range []string{"m", "m/12381/3600/0/0/0", "m/12381/3600/0/0", "m/12381/3600/1/0/0"} {
+		expected, err := util.PrivateKeyFromSeedAndPath(seed, path)
+		require.NoError(t, err)
+		// Derive twice to exercise the cache.
+		for i := 0; i < 2; i++ {
+			key, err := deriver.PrivateKey(path)
+			require.NoError(t, err)
+			assert.Equal(t, expected.Marshal(), key.Marshal())
+		}
+		pubKey, err := deriver.PublicKey(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected.PublicKey().Marshal(), pubKey.Marshal())
+	}
+}
+
+func TestPrivateKeysFromSeedAndPathTemplate(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+
+	_, err := util.PrivateKeysFromSeedAndPathTemplate(seed[:15], "m/12381/3600/{0..2}/0/0")
+	require.EqualError(t, err, "seed must be at least 128 bits")
+	_, err = util.PrivateKeysFromSeedAndPathTemplate(seed, "m/12381/3600/{2..0}/0/0")
+	require.EqualError(t, err, `invalid range "2..0" at path component 3`)
+
+	keys, err := util.PrivateKeysFromSeedAndPathTemplate(seed, "m/12381/3600/{0..2,7}/0/0")
+	require.NoError(t, err)
+	require.Len(t, keys, 4)
+	for i, account := range []uint32{0, 1, 2, 7} {
+		assert.Equal(t, util.SigningPath(account), keys[i].Path)
+		expected, err := util.PrivateKeyFromSeedAndPath(seed, util.SigningPath(account))
+		require.NoError(t, err)
+		assert.Equal(t, expected.Marshal(), keys[i].PrivateKey.Marshal())
+	}
+	assert.Equal(t, _byteArray("b3d758f5ff8d1bdfe4b744e2372b5f37261619f4a45c97db829675e4669732781c858caaec6dbe86c2d096070de5c992"), keys[0].PrivateKey.PublicKey().Marshal())
+}
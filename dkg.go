@@ -0,0 +1,347 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	bls "github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// DKGMessage is a message exchanged between participants during distributed key generation.
+type DKGMessage struct {
+	// From is the index of the sending participant.
+	From uint64
+	// To is the index of the receiving participant, or 0 if the message is for all participants.
+	To uint64
+	// Commitments are the sender's commitments to its sharing polynomial, sent to all participants.
+	Commitments [][]byte
+	// Share is the sender's share for the receiving participant.
+	Share []byte
+}
+
+// DKGTransport delivers messages between participants during distributed key generation.
+// Shares are sent in the clear, so implementations must authenticate and encrypt messages
+// when participants are not in the same process.
+type DKGTransport interface {
+	// Send sends a message.
+	Send(ctx context.Context, msg *DKGMessage) error
+	// Receive receives the next message for this participant.
+	Receive(ctx context.Context) (*DKGMessage, error)
+}
+
+// DKGResult is the result of distributed key generation for a single participant.
+type DKGResult struct {
+	// Index is the index of the participant.
+	Index uint64
+	// Threshold is the number of participants required to generate a signature.
+	Threshold int
+	// PrivateKey is the participant's share of the group private key.
+	PrivateKey *e2types.BLSPrivateKey
+	// PublicKey is the group public key.
+	PublicKey e2types.PublicKey
+	// Commitments are the commitments to the group sharing polynomial, from which the public key share of
+	// any participant can be obtained with PublicKeyShare.
+	Commitments [][]byte
+}
+
+// GenerateDistributedKey runs a Joint-Feldman distributed key generation as the participant with the given index.
+// Each participant deals a random secret to all participants with verifiable shares, and the group key is the
+// sum of the dealt secrets, so no single participant learns the group private key.
+// All participants must call this with the same participants and threshold.
+func GenerateDistributedKey(ctx context.Context,
+	index uint64,
+	participants []uint64,
+	threshold int,
+	transport DKGTransport,
+) (
+	*DKGResult,
+	error,
+) {
+	if err := checkDKGParameters(index, participants, threshold); err != nil {
+		return nil, err
+	}
+	if transport == nil {
+		return nil, errors.New("no transport supplied")
+	}
+
+	secret, err := rand.Int(rand.Reader, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate secret")
+	}
+	coefficients, err := randomPolynomial(secret, threshold)
+	if err != nil {
+		return nil, err
+	}
+	dealt, err := thresholdKeyFromPolynomial(coefficients, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deal our commitments and shares.
+	if err := transport.Send(ctx, &DKGMessage{From: index, Commitments: dealt.Commitments}); err != nil {
+		return nil, errors.Wrap(err, "failed to send commitments")
+	}
+	ownShare := evaluatePolynomial(coefficients, new(big.Int).SetUint64(index))
+	for _, participant := range participants {
+		if participant == index {
+			continue
+		}
+		share := evaluatePolynomial(coefficients, new(big.Int).SetUint64(participant))
+		if err := transport.Send(ctx, &DKGMessage{From: index, To: participant, Share: i2OSP(share, 32)}); err != nil {
+			return nil, errors.Wrapf(err, "failed to send share to participant %d", participant)
+		}
+	}
+
+	commitments, shares, err := receiveDealings(ctx, index, participants, threshold, transport)
+	if err != nil {
+		return nil, err
+	}
+	commitments[index] = dealt.Commitments
+	shares[index] = ownShare
+
+	return combineDealings(index, participants, threshold, commitments, shares)
+}
+
+// checkDKGParameters checks the parameters for distributed key generation.
+func checkDKGParameters(index uint64, participants []uint64, threshold int) error {
+	if len(participants) == 0 {
+		return errors.New("no participants supplied")
+	}
+	if threshold < 1 {
+		return errors.New("threshold must be at least 1")
+	}
+	if threshold > len(participants) {
+		return errors.New("threshold must be at most the number of participants")
+	}
+	seen := make(map[uint64]bool, len(participants))
+	for _, participant := range participants {
+		if participant == 0 {
+			return errors.New("participant index must be at least 1")
+		}
+		if seen[participant] {
+			return fmt.Errorf("duplicate participant %d", participant)
+		}
+		seen[participant] = true
+	}
+	if !seen[index] {
+		return fmt.Errorf("participant %d not in participants", index)
+	}
+
+	return nil
+}
+
+// receiveDealings receives and verifies the commitments and shares dealt by all other participants.
+func receiveDealings(ctx context.Context,
+	index uint64,
+	participants []uint64,
+	threshold int,
+	transport DKGTransport,
+) (
+	map[uint64][][]byte,
+	map[uint64]*big.Int,
+	error,
+) {
+	expected := make(map[uint64]bool, len(participants))
+	for _, participant := range participants {
+		if participant != index {
+			expected[participant] = true
+		}
+	}
+
+	commitments := make(map[uint64][][]byte, len(participants))
+	shares := make(map[uint64]*big.Int, len(participants))
+	for len(commitments) < len(expected) || len(shares) < len(expected) {
+		msg, err := transport.Receive(ctx)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to receive message")
+		}
+		if !expected[msg.From] {
+			return nil, nil, fmt.Errorf("message from unexpected participant %d", msg.From)
+		}
+		switch msg.To {
+		case 0:
+			if _, exists := commitments[msg.From]; exists {
+				return nil, nil, fmt.Errorf("duplicate commitments from participant %d", msg.From)
+			}
+			if len(msg.Commitments) != threshold {
+				return nil, nil, fmt.Errorf("participant %d sent %d commitments, expected %d", msg.From, len(msg.Commitments), threshold)
+			}
+			commitments[msg.From] = msg.Commitments
+		case index:
+			if _, exists := shares[msg.From]; exists {
+				return nil, nil, fmt.Errorf("duplicate share from participant %d", msg.From)
+			}
+			if len(msg.Share) != 32 {
+				return nil, nil, fmt.Errorf("participant %d sent invalid share", msg.From)
+			}
+			shares[msg.From] = osToIP(msg.Share)
+		default:
+			return nil, nil, fmt.Errorf("message from participant %d for participant %d", msg.From, msg.To)
+		}
+	}
+
+	for dealer, share := range shares {
+		sk, err := e2types.BLSPrivateKeyFromBytes(i2OSP(share, 32))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid share from participant %d", dealer)
+		}
+		if err := VerifyThresholdKeyShare(&ThresholdKeyShare{Index: index, PrivateKey: sk}, commitments[dealer]); err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid share from participant %d", dealer)
+		}
+	}
+
+	return commitments, shares, nil
+}
+
+// combineDealings combines the verified dealings of all participants to create the group key share.
+func combineDealings(index uint64,
+	participants []uint64,
+	threshold int,
+	commitments map[uint64][][]byte,
+	shares map[uint64]*big.Int,
+) (
+	*DKGResult,
+	error,
+) {
+	total := big.NewInt(0)
+	combined := make([]bls.PublicKey, threshold)
+	for i, participant := range participants {
+		total.Add(total, shares[participant])
+		for j := range combined {
+			var commitment bls.PublicKey
+			if err := commitment.Deserialize(commitments[participant][j]); err != nil {
+				return nil, errors.Wrapf(err, "invalid commitment %d from participant %d", j, participant)
+			}
+			if i == 0 {
+				combined[j] = commitment
+			} else {
+				combined[j].Add(&commitment)
+			}
+		}
+	}
+	total.Mod(total, r)
+
+	sk, err := e2types.BLSPrivateKeyFromBytes(i2OSP(total, 32))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid group key share")
+	}
+	res := &DKGResult{
+		Index:       index,
+		Threshold:   threshold,
+		PrivateKey:  sk,
+		Commitments: make([][]byte, threshold),
+	}
+	for i := range combined {
+		res.Commitments[i] = combined[i].Serialize()
+	}
+	res.PublicKey, err = e2types.BLSPublicKeyFromBytes(res.Commitments[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid group public key")
+	}
+
+	return res, nil
+}
+
+// MemoryDKGNetwork is an in-memory network connecting participants in distributed key generation.
+type MemoryDKGNetwork struct {
+	queues map[uint64]chan *DKGMessage
+}
+
+// NewMemoryDKGNetwork creates an in-memory network for the given participants.
+func NewMemoryDKGNetwork(participants []uint64) *MemoryDKGNetwork {
+	queues := make(map[uint64]chan *DKGMessage, len(participants))
+	for _, participant := range participants {
+		// Each participant receives at most a commitment and a share from every other participant.
+		queues[participant] = make(chan *DKGMessage, 2*len(participants))
+	}
+
+	return &MemoryDKGNetwork{
+		queues: queues,
+	}
+}
+
+// Transport returns the transport for a participant.
+func (n *MemoryDKGNetwork) Transport(participant uint64) (DKGTransport, error) {
+	if _, exists := n.queues[participant]; !exists {
+		return nil, fmt.Errorf("participant %d not in network", participant)
+	}
+
+	return &memoryDKGTransport{
+		network:     n,
+		participant: participant,
+	}, nil
+}
+
+// memoryDKGTransport is the transport for a single participant in an in-memory network.
+type memoryDKGTransport struct {
+	network     *MemoryDKGNetwork
+	participant uint64
+}
+
+// Send sends a message.
+func (t *memoryDKGTransport) Send(ctx context.Context, msg *DKGMessage) error {
+	if msg == nil {
+		return errors.New("no message supplied")
+	}
+	if msg.From != t.participant {
+		return fmt.Errorf("participant %d cannot send as participant %d", t.participant, msg.From)
+	}
+
+	if msg.To != 0 {
+		queue, exists := t.network.queues[msg.To]
+		if !exists {
+			return fmt.Errorf("participant %d not in network", msg.To)
+		}
+
+		return deliver(ctx, queue, msg)
+	}
+	for participant, queue := range t.network.queues {
+		if participant == t.participant {
+			continue
+		}
+		if err := deliver(ctx, queue, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Receive receives the next message for this participant.
+func (t *memoryDKGTransport) Receive(ctx context.Context) (*DKGMessage, error) {
+	select {
+	case msg := <-t.network.queues[t.participant]:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// deliver places a message on a queue.
+func deliver(ctx context.Context, queue chan *DKGMessage, msg *DKGMessage) error {
+	select {
+	case queue <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+// tamperingTransport corrupts the shares sent by its participant.
+type tamperingTransport struct {
+	util.DKGTransport
+}
+
+func (t *tamperingTransport) Send(ctx context.Context, msg *util.DKGMessage) error {
+	if msg.Share != nil {
+		share := append([]byte{}, msg.Share...)
+		share[31] ^= 0x01
+		msg = &util.DKGMessage{From: msg.From, To: msg.To, Share: share}
+	}
+
+	return t.DKGTransport.Send(ctx, msg)
+}
+
+// runDKG runs distributed key generation for all participants, returning their results and errors.
+func runDKG(t *testing.T,
+	participants []uint64,
+	threshold int,
+	wrap func(uint64, util.DKGTransport) util.DKGTransport,
+) (
+	map[uint64]*util.DKGResult,
+	map[uint64]error,
+) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	network := util.NewMemoryDKGNetwork(participants)
+	results := make(map[uint64]*util.DKGResult)
+	errs := make(map[uint64]error)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, participant := range participants {
+		transport, err := network.Transport(participant)
+		require.NoError(t, err)
+		if wrap != nil {
+			transport = wrap(participant, transport)
+		}
+		wg.Add(1)
+		go func(participant uint64, transport util.DKGTransport) {
+			defer wg.Done()
+			result, err := util.GenerateDistributedKey(ctx, participant, participants, threshold, transport)
+			mutex.Lock()
+			results[participant] = result
+			errs[participant] = err
+			mutex.Unlock()
+		}(participant, transport)
+	}
+	wg.Wait()
+
+	return results, errs
+}
+
+func TestGenerateDistributedKey(t *testing.T) {
+	participants := []uint64{1, 2, 3, 4, 5}
+	threshold := 3
+	results, errs := runDKG(t, participants, threshold, nil)
+
+	msg := util.SHA256([]byte("message"))
+	partials := make([]*util.PartialSignature, 0, len(participants))
+	for _, participant := range participants {
+		require.NoError(t, errs[participant])
+		result := results[participant]
+		assert.Equal(t, participant, result.Index)
+		assert.Equal(t, threshold, result.Threshold)
+		assert.Equal(t, results[1].PublicKey.Marshal(), result.PublicKey.Marshal())
+		assert.Equal(t, results[1].Commitments, result.Commitments)
+		require.NoError(t, util.VerifyThresholdKeyShare(&util.ThresholdKeyShare{
+			Index:      participant,
+			PrivateKey: result.PrivateKey,
+		}, result.Commitments))
+		partials = append(partials, &util.PartialSignature{
+			Index:     participant,
+			Signature: result.PrivateKey.Sign(msg),
+		})
+	}
+
+	// Any threshold participants can sign for the group key.
+	for i := 0; i+threshold <= len(partials); i++ {
+		sig, err := util.RecoverSignature(partials[i : i+threshold])
+		require.NoError(t, err)
+		assert.True(t, sig.Verify(msg, results[1].PublicKey))
+	}
+}
+
+func TestGenerateDistributedKeyTampered(t *testing.T) {
+	participants := []uint64{1, 2, 3}
+	_, errs := runDKG(t, participants, 2, func(participant uint64, transport util.DKGTransport) util.DKGTransport {
+		if participant == 2 {
+			return &tamperingTransport{DKGTransport: transport}
+		}
+
+		return transport
+	})
+
+	require.EqualError(t, errs[1], "invalid share from participant 2: share 1 does not match commitments")
+	require.NoError(t, errs[2])
+	require.EqualError(t, errs[3], "invalid share from participant 2: share 3 does not match commitments")
+}
+
+func TestGenerateDistributedKeyParameters(t *testing.T) {
+	ctx := context.Background()
+	network := util.NewMemoryDKGNetwork([]uint64{1, 2, 3})
+	transport, err := network.Transport(1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		index        uint64
+		participants []uint64
+		threshold    int
+		transport    util.DKGTransport
+		err          string
+	}{
+		{
+			name:      "NoParticipants",
+			index:     1,
+			threshold: 1,
+			transport: transport,
+			err:       "no participants supplied",
+		},
+		{
+			name:         "ThresholdZero",
+			index:        1,
+			participants: []uint64{1, 2, 3},
+			transport:    transport,
+			err:          "threshold must be at least 1",
+		},
+		{
+			name:         "ThresholdTooHigh",
+			index:        1,
+			participants: []uint64{1, 2, 3},
+			threshold:    4,
+			transport:    transport,
+			err:          "threshold must be at most the number of participants",
+		},
+		{
+			name:         "ZeroParticipant",
+			index:        1,
+			participants: []uint64{0, 1, 2},
+			threshold:    2,
+			transport:    transport,
+			err:          "participant index must be at least 1",
+		},
+		{
+			name:         "DuplicateParticipant",
+			index:        1,
+			participants: []uint64{1, 2, 2},
+			threshold:    2,
+			transport:    transport,
+			err:          "duplicate participant 2",
+		},
+		{
+			name:         "NotParticipant",
+			index:        4,
+			participants: []uint64{1, 2, 3},
+			threshold:    2,
+			transport:    transport,
+			err:          "participant 4 not in participants",
+		},
+		{
+			name:         "NoTransport",
+			index:        1,
+			participants: []uint64{1, 2, 3},
+			threshold:    2,
+			err:          "no transport supplied",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := util.GenerateDistributedKey(ctx, test.index, test.participants, test.threshold, test.transport)
+			require.EqualError(t, err, test.err)
+		})
+	}
+
+	_, err = network.Transport(4)
+	require.EqualError(t, err, "participant 4 not in network")
+}
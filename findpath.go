@@ -0,0 +1,110 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrPathNotFound is returned when a public key is not found within the searched paths.
+var ErrPathNotFound = errors.New("path not found")
+
+// FindPath finds the ERC-2334 path at which the given public key is derived from the seed.
+// The signing and withdrawal paths of accounts 0 to maxAccounts-1 are searched in parallel, and
+// ErrPathNotFound is returned if the public key is not found.
+func FindPath(ctx context.Context, seed []byte, pubKey []byte, maxAccounts uint32) (string, error) {
+	if len(pubKey) != 48 {
+		return "", errors.New("public key must be 48 bytes")
+	}
+	// The deriver is shared by the workers, so intermediate keys common to all accounts are derived once.
+	deriver, err := NewKeyDeriver(seed)
+	if err != nil {
+		return "", err
+	}
+
+	searchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	accounts := make(chan uint32)
+	go func() {
+		defer close(accounts)
+		for account := uint32(0); account < maxAccounts; account++ {
+			select {
+			case accounts <- account:
+			case <-searchCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	var res string
+	var resErr error
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for account := range accounts {
+				path, err := matchAccount(deriver, pubKey, account)
+				if err != nil || path != "" {
+					once.Do(func() {
+						res, resErr = path, err
+						cancel()
+					})
+
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	switch {
+	case resErr != nil:
+		return "", resErr
+	case res != "":
+		return res, nil
+	case ctx.Err() != nil:
+		return "", ctx.Err()
+	default:
+		return "", ErrPathNotFound
+	}
+}
+
+// matchAccount returns the path of the account's signing or withdrawal key if it matches the public key.
+// The withdrawal key is the parent of the signing key, so is obtained from the deriver's cache.
+func matchAccount(deriver *KeyDeriver, pubKey []byte, account uint32) (string, error) {
+	for _, path := range []string{
+		SigningPath(account),
+		WithdrawalPath(account),
+	} {
+		key, err := deriver.PublicKey(path)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to derive key at %s", path)
+		}
+		if bytes.Equal(key.Marshal(), pubKey) {
+			return path, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,110 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestFindPath(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	pubKey := func(path string) []byte {
+		key, err := util.PrivateKeyFromSeedAndPath(seed, path)
+		require.NoError(t, err)
+
+		return key.PublicKey().Marshal()
+	}
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := util.FindPath(context.Background(), seed[:15], pubKey("m/12381/3600/0/0/0"), 10)
+	require.EqualError(t, err, "seed must be at least 128 bits")
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		pubKey      []byte
+		maxAccounts uint32
+		err         string
+		path        string
+	}{
+		{
+			name:        "PubKeyInvalid",
+			ctx:         context.Background(),
+			pubKey:      []byte{0x01},
+			maxAccounts: 10,
+			err:         "public key must be 48 bytes",
+		},
+		{
+			name:        "Signing",
+			ctx:         context.Background(),
+			pubKey:      pubKey("m/12381/3600/3/0/0"),
+			maxAccounts: 10,
+			path:        "m/12381/3600/3/0/0",
+		},
+		{
+			name:        "Withdrawal",
+			ctx:         context.Background(),
+			pubKey:      pubKey("m/12381/3600/7/0"),
+			maxAccounts: 10,
+			path:        "m/12381/3600/7/0",
+		},
+		{
+			name:        "BeyondLimit",
+			ctx:         context.Background(),
+			pubKey:      pubKey("m/12381/3600/7/0"),
+			maxAccounts: 7,
+			err:         "path not found",
+		},
+		{
+			name:        "OtherPath",
+			ctx:         context.Background(),
+			pubKey:      pubKey("m/12381/60/0/0"),
+			maxAccounts: 10,
+			err:         "path not found",
+		},
+		{
+			name:        "Cancelled",
+			ctx:         cancelledCtx,
+			pubKey:      make([]byte, 48),
+			maxAccounts: 1000,
+			err:         "context canceled",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, err := util.FindPath(test.ctx, seed, test.pubKey, test.maxAccounts)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.path, path)
+			}
+		})
+	}
+}
+
+func TestFindPathNotFound(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	_, err := util.FindPath(context.Background(), seed, make([]byte, 48), 2)
+	assert.ErrorIs(t, err, util.ErrPathNotFound)
+}
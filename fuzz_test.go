@@ -0,0 +1,94 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+// maxFuzzPathComponents limits the depth of fuzzed paths, as each level of derivation is expensive.
+const maxFuzzPathComponents = 6
+
+func FuzzPrivateKeyFromSeedAndPath(f *testing.F) {
+	f.Add(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m/12381/3600/0/0/0")
+	f.Add(_byteArray("0102030405060708090a0b0c0d0e0f10"), "m/12381/3600/4294967295/0")
+	f.Add(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m")
+	f.Add(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m/12381//0")
+	f.Add(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m/m")
+	f.Add(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m/-1")
+	f.Add(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m/4294967296")
+	f.Add(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m/+1")
+	f.Add([]byte{}, "m/12381")
+
+	f.Fuzz(func(t *testing.T, seed []byte, path string) {
+		if strings.Count(path, "/") >= maxFuzzPathComponents {
+			t.Skip()
+		}
+		sk, err := util.PrivateKeyFromSeedAndPath(seed, path)
+		if err != nil {
+			return
+		}
+		if len(seed) < 16 {
+			t.Fatalf("short seed of %d bytes accepted", len(seed))
+		}
+		if !strings.HasPrefix(path, "m") {
+			t.Fatalf("path %q without master accepted", path)
+		}
+		skBytes := sk.Marshal()
+		if len(skBytes) != 32 {
+			t.Fatalf("private key is %d bytes", len(skBytes))
+		}
+
+		// Derivation must be deterministic.
+		sk2, err := util.PrivateKeyFromSeedAndPath(seed, path)
+		if err != nil {
+			t.Fatalf("second derivation failed: %v", err)
+		}
+		if !bytes.Equal(skBytes, sk2.Marshal()) {
+			t.Fatalf("derivation of %q not deterministic", path)
+		}
+	})
+}
+
+func FuzzDeriveMasterSK(f *testing.F) {
+	f.Add(_byteArray("c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"))
+	f.Add(_byteArray("3141592653589793238462643383279502884197169399375105820974944592"))
+	f.Add(_byteArray("0102030405060708090a0b0c0d0e0f"))
+	f.Add([]byte{})
+
+	r, _ := new(big.Int).SetString("52435875175126190479447740508185965837690552500527637822603658699938581184513", 10)
+	f.Fuzz(func(t *testing.T, seed []byte) {
+		sk, err := util.DeriveMasterSK(seed)
+		if len(seed) < 16 {
+			if err == nil {
+				t.Fatalf("short seed of %d bytes accepted", len(seed))
+			}
+
+			return
+		}
+		if err != nil {
+			t.Fatalf("derivation failed: %v", err)
+		}
+		if sk.Sign() <= 0 || sk.Cmp(r) >= 0 {
+			t.Fatalf("master key %v out of range", sk)
+		}
+	})
+}
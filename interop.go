@@ -0,0 +1,82 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// InteropPrivateKey returns the interop (mock-start) private key with the given index.
+// Follows the eth2.0-pm interop specification, in which the key is the little-endian integer of
+// SHA256(uint256_le(index)) mod r.
+func InteropPrivateKey(index uint64) (*e2types.BLSPrivateKey, error) {
+	input := make([]byte, 32)
+	copy(input, uint64ToBytes(index))
+	hash := SHA256(input)
+
+	// Reverse the hash to obtain its little-endian integer value.
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+	sk := new(big.Int).Mod(osToIP(hash), r)
+
+	return e2types.BLSPrivateKeyFromBytes(i2OSP(sk, 32))
+}
+
+// InteropPrivateKeys returns count interop private keys, starting with the given index.
+func InteropPrivateKeys(first uint64, count uint64) ([]*e2types.BLSPrivateKey, error) {
+	if first+count < first {
+		return nil, errors.New("index range overflows")
+	}
+
+	res := make([]*e2types.BLSPrivateKey, count)
+	for i := uint64(0); i < count; i++ {
+		key, err := InteropPrivateKey(first + i)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate interop key %d", first+i)
+		}
+		res[i] = key
+	}
+
+	return res, nil
+}
+
+// InteropDepositData returns the interop deposit data for count validators, starting with the given index.
+// Each deposit is of MaxEffectiveBalance, with BLS withdrawal credentials of the validator's own key, and is signed
+// for the network with the given genesis fork version.
+func InteropDepositData(first uint64, count uint64, genesisForkVersion []byte) ([]*DepositData, error) {
+	keys, err := InteropPrivateKeys(first, count)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*DepositData, len(keys))
+	for i, key := range keys {
+		withdrawalCredentials, err := BLSWithdrawalCredentials(key.PublicKey().Marshal())
+		if err != nil {
+			return nil, err
+		}
+		res[i], err = NewDepositData(key, withdrawalCredentials, Gwei(MaxEffectiveBalance), genesisForkVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create interop deposit data %d", first+uint64(i))
+		}
+	}
+
+	return res, nil
+}
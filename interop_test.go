@@ -0,0 +1,96 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestInteropPrivateKey(t *testing.T) {
+	tests := []struct {
+		index      uint64
+		privateKey string
+		pubKey     string
+	}{
+		{
+			index:      0,
+			privateKey: "25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866",
+			pubKey:     "a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+		},
+		{
+			index:      1,
+			privateKey: "51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000",
+			pubKey:     "b89bebc699769726a318c8e9971bd3171297c61aea4a6578a7a4f94b547dcba5bac16a89108b6b6a1fe3695d1a874a0b",
+		},
+		{
+			index:      2,
+			privateKey: "315ed405fafe339603932eebe8dbfd650ce5dafa561f6928664c75db85f97857",
+		},
+	}
+
+	for _, test := range tests {
+		key, err := util.InteropPrivateKey(test.index)
+		require.NoError(t, err)
+		assert.Equal(t, _byteArray(test.privateKey), key.Marshal())
+		if test.pubKey != "" {
+			assert.Equal(t, _byteArray(test.pubKey), key.PublicKey().Marshal())
+		}
+	}
+}
+
+func TestInteropPrivateKeys(t *testing.T) {
+	_, err := util.InteropPrivateKeys(math.MaxUint64, 2)
+	require.EqualError(t, err, "index range overflows")
+
+	keys, err := util.InteropPrivateKeys(1, 2)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, _byteArray("51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"), keys[0].Marshal())
+	assert.Equal(t, _byteArray("315ed405fafe339603932eebe8dbfd650ce5dafa561f6928664c75db85f97857"), keys[1].Marshal())
+}
+
+func TestInteropDepositData(t *testing.T) {
+	genesisForkVersion := []byte{0x00, 0x00, 0x00, 0x00}
+	deposits, err := util.InteropDepositData(0, 2, genesisForkVersion)
+	require.NoError(t, err)
+	require.Len(t, deposits, 2)
+
+	domain, err := e2types.ComputeDomain(e2types.DomainDeposit, genesisForkVersion, e2types.ZeroGenesisValidatorsRoot)
+	require.NoError(t, err)
+	for i, deposit := range deposits {
+		key, err := util.InteropPrivateKey(uint64(i))
+		require.NoError(t, err)
+		assert.Equal(t, key.PublicKey().Marshal(), deposit.PublicKey)
+		withdrawalCredentials, err := util.BLSWithdrawalCredentials(deposit.PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, withdrawalCredentials, deposit.WithdrawalCredentials)
+		assert.Equal(t, util.Gwei(util.MaxEffectiveBalance), deposit.Amount)
+
+		messageRoot, err := deposit.Message().HashTreeRoot()
+		require.NoError(t, err)
+		signingRoot, err := util.ComputeSigningRoot(messageRoot, domain)
+		require.NoError(t, err)
+		signature, err := e2types.BLSSignatureFromBytes(deposit.Signature)
+		require.NoError(t, err)
+		assert.True(t, signature.Verify(signingRoot, key.PublicKey()))
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/hkdf"
+)
+
+// KeyGenDraft is a version of the IRTF BLS signature draft's KeyGen procedure.
+type KeyGenDraft int
+
+const (
+	// KeyGenDraft0 is KeyGen as defined in drafts 00 and 01.
+	// The salt is used as-is, IKM and key_info are not suffixed, and key_info is not supported.
+	KeyGenDraft0 KeyGenDraft = iota
+	// KeyGenDraft2 is KeyGen as defined in drafts 02 and 03.
+	// The salt is used as-is, IKM is suffixed with I2OSP(0, 1) and key_info with I2OSP(L, 2).
+	KeyGenDraft2
+	// KeyGenDraft4 is KeyGen as defined in draft 04 onwards, and used by ERC-2333.
+	// As KeyGenDraft2, but the salt is hashed before each attempt and attempts repeat until the key is non-zero.
+	KeyGenDraft4
+)
+
+// keyGenSalt is the default KeyGen salt.
+const keyGenSalt = "BLS-SIG-KEYGEN-SALT-"
+
+type keyGenParameters struct {
+	salt  []byte
+	draft KeyGenDraft
+}
+
+// KeyGenOption is the interface for KeyGen options.
+type KeyGenOption interface {
+	apply(p *keyGenParameters)
+}
+
+type keyGenOptionFunc func(*keyGenParameters)
+
+func (f keyGenOptionFunc) apply(p *keyGenParameters) {
+	f(p)
+}
+
+// WithKeyGenSalt sets the salt used by KeyGen.
+// It defaults to "BLS-SIG-KEYGEN-SALT-".
+func WithKeyGenSalt(salt []byte) KeyGenOption {
+	return keyGenOptionFunc(func(p *keyGenParameters) {
+		p.salt = salt
+	})
+}
+
+// WithKeyGenDraft sets the version of the draft that KeyGen follows.
+// It defaults to KeyGenDraft4.
+func WithKeyGenDraft(draft KeyGenDraft) KeyGenOption {
+	return keyGenOptionFunc(func(p *keyGenParameters) {
+		p.draft = draft
+	})
+}
+
+// KeyGen generates a secret key from input keying material and key information.
+// Follows KeyGen from the IRTF BLS signature draft.
+func KeyGen(ikm []byte, keyInfo []byte, opts ...KeyGenOption) (*big.Int, error) {
+	parameters := keyGenParameters{
+		salt:  []byte(keyGenSalt),
+		draft: KeyGenDraft4,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(&parameters)
+		}
+	}
+
+	if len(ikm) < 32 {
+		return nil, errors.New("IKM must be at least 32 bytes")
+	}
+	if len(parameters.salt) == 0 {
+		return nil, errors.New("no salt specified")
+	}
+
+	return keyGen(ikm, keyInfo, &parameters)
+}
+
+// keyGen generates a secret key without checking its parameters.
+func keyGen(ikm []byte, keyInfo []byte, parameters *keyGenParameters) (*big.Int, error) {
+	switch parameters.draft {
+	case KeyGenDraft0:
+		if len(keyInfo) != 0 {
+			return nil, errors.New("key info not supported by draft 0")
+		}
+
+		return keyGenAttempt(ikm, nil, parameters.salt)
+	case KeyGenDraft2:
+		return keyGenAttempt(keyGenIKM(ikm), keyGenInfo(keyInfo), parameters.salt)
+	case KeyGenDraft4:
+		salt := parameters.salt
+		sk := big.NewInt(0)
+		for sk.Sign() == 0 {
+			salt = SHA256(salt)
+			var err error
+			sk, err = hkdfModR(keyGenIKM(ikm), keyGenInfo(keyInfo), salt)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return sk, nil
+	default:
+		return nil, fmt.Errorf("unsupported draft %d", parameters.draft)
+	}
+}
+
+// keyGenAttempt generates a secret key in a single attempt, failing if the key is zero.
+func keyGenAttempt(ikm []byte, info []byte, salt []byte) (*big.Int, error) {
+	sk, err := hkdfModR(ikm, info, salt)
+	if err != nil {
+		return nil, err
+	}
+	if sk.Sign() == 0 {
+		return nil, errors.New("generated key is zero")
+	}
+
+	return sk, nil
+}
+
+// keyGenIKM returns IKM || I2OSP(0, 1).
+func keyGenIKM(ikm []byte) []byte {
+	res := make([]byte, len(ikm)+1)
+	copy(res, ikm)
+
+	return res
+}
+
+// keyGenInfo returns key_info || I2OSP(L, 2).
+func keyGenInfo(keyInfo []byte) []byte {
+	res := make([]byte, len(keyInfo), len(keyInfo)+2)
+	copy(res, keyInfo)
+
+	return append(res, i2OSP(big.NewInt(int64(l)), 2)...)
+}
+
+// hkdfModR hashes input keying material into the subgroup of the BLS12-381 private keys.
+func hkdfModR(ikm []byte, info []byte, salt []byte) (*big.Int, error) {
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	okm := hkdf.Expand(sha256.New, prk, info)
+	okmOut := make([]byte, l)
+	read, err := okm.Read(okmOut)
+	if err != nil {
+		return nil, err
+	}
+	if read != l {
+		return nil, fmt.Errorf("only read %d bytes", read)
+	}
+
+	return new(big.Int).Mod(osToIP(okmOut), r), nil
+}
@@ -0,0 +1,127 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"crypto/sha256"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+	"golang.org/x/crypto/hkdf"
+)
+
+// referenceKeyGen is a single KeyGen attempt written directly from the draft.
+func referenceKeyGen(t *testing.T, ikm []byte, info []byte, salt []byte) *big.Int {
+	t.Helper()
+
+	okm := make([]byte, 48)
+	_, err := io.ReadFull(hkdf.New(sha256.New, ikm, salt, info), okm)
+	require.NoError(t, err)
+	r, _ := new(big.Int).SetString("52435875175126190479447740508185965837690552500527637822603658699938581184513", 10)
+
+	return new(big.Int).Mod(new(big.Int).SetBytes(okm), r)
+}
+
+func TestKeyGen(t *testing.T) {
+	ikm := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	masterSK, err := util.DeriveMasterSK(ikm)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		ikm     []byte
+		keyInfo []byte
+		opts    []util.KeyGenOption
+		err     string
+		sk      *big.Int
+	}{
+		{
+			name: "IKMShort",
+			ikm:  ikm[:31],
+			err:  "IKM must be at least 32 bytes",
+		},
+		{
+			name: "SaltEmpty",
+			ikm:  ikm,
+			opts: []util.KeyGenOption{util.WithKeyGenSalt([]byte{})},
+			err:  "no salt specified",
+		},
+		{
+			name: "DraftUnsupported",
+			ikm:  ikm,
+			opts: []util.KeyGenOption{util.WithKeyGenDraft(util.KeyGenDraft(99))},
+			err:  "unsupported draft 99",
+		},
+		{
+			name:    "Draft0KeyInfo",
+			ikm:     ikm,
+			keyInfo: []byte("info"),
+			opts:    []util.KeyGenOption{util.WithKeyGenDraft(util.KeyGenDraft0)},
+			err:     "key info not supported by draft 0",
+		},
+		{
+			name: "Default",
+			ikm:  ikm,
+			sk:   masterSK,
+		},
+		{
+			name: "Draft4",
+			ikm:  ikm,
+			opts: []util.KeyGenOption{util.WithKeyGenDraft(util.KeyGenDraft4)},
+			sk:   masterSK,
+		},
+		{
+			name:    "Draft4KeyInfo",
+			ikm:     ikm,
+			keyInfo: []byte("info"),
+			sk:      referenceKeyGen(t, append(ikm, 0x00), []byte("info\x00\x30"), util.SHA256(salt)),
+		},
+		{
+			name: "Draft4Salt",
+			ikm:  ikm,
+			opts: []util.KeyGenOption{util.WithKeyGenSalt([]byte("salt"))},
+			sk:   referenceKeyGen(t, append(ikm, 0x00), []byte{0x00, 0x30}, util.SHA256([]byte("salt"))),
+		},
+		{
+			name:    "Draft2",
+			ikm:     ikm,
+			keyInfo: []byte("info"),
+			opts:    []util.KeyGenOption{util.WithKeyGenDraft(util.KeyGenDraft2)},
+			sk:      referenceKeyGen(t, append(ikm, 0x00), []byte("info\x00\x30"), salt),
+		},
+		{
+			name: "Draft0",
+			ikm:  ikm,
+			opts: []util.KeyGenOption{util.WithKeyGenDraft(util.KeyGenDraft0)},
+			sk:   referenceKeyGen(t, ikm, nil, salt),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sk, err := util.KeyGen(test.ikm, test.keyInfo, test.opts...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.sk, sk)
+			}
+		})
+	}
+}
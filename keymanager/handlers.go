@@ -0,0 +1,462 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	util "github.com/wealdtech/go-eth2-util"
+	"github.com/wealdtech/go-eth2-util/slashingprotection"
+)
+
+const (
+	statusImported  = "imported"
+	statusDuplicate = "duplicate"
+	statusDeleted   = "deleted"
+	statusNotActive = "not_active"
+	statusNotFound  = "not_found"
+	statusError     = "error"
+)
+
+type errorJSON struct {
+	Message string `json:"message"`
+}
+
+type dataJSON struct {
+	Data any `json:"data"`
+}
+
+type statusJSON struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type keystoreJSON struct {
+	ValidatingPubkey string `json:"validating_pubkey"`
+	DerivationPath   string `json:"derivation_path,omitempty"`
+	ReadOnly         bool   `json:"readonly"`
+}
+
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection"`
+}
+
+type deleteRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+
+type deleteKeystoresResponse struct {
+	Data               []*statusJSON `json:"data"`
+	SlashingProtection string        `json:"slashing_protection"`
+}
+
+type remoteKeyJSON struct {
+	Pubkey   string `json:"pubkey"`
+	URL      string `json:"url"`
+	ReadOnly bool   `json:"readonly"`
+}
+
+type importRemoteKeysRequest struct {
+	RemoteKeys []*remoteKeyJSON `json:"remote_keys"`
+}
+
+type feeRecipientJSON struct {
+	Pubkey     string `json:"pubkey,omitempty"`
+	EthAddress string `json:"ethaddress"`
+}
+
+// listKeystores lists the local keys.
+func (s *Service) listKeystores(w http.ResponseWriter, r *http.Request) {
+	keystores, err := s.store.Keystores(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	data := make([]*keystoreJSON, len(keystores))
+	for i := range keystores {
+		data[i] = &keystoreJSON{
+			ValidatingPubkey: fmt.Sprintf("%#x", keystores[i].PublicKey),
+			DerivationPath:   keystores[i].Path,
+			ReadOnly:         keystores[i].ReadOnly,
+		}
+	}
+	writeJSON(w, http.StatusOK, &dataJSON{Data: data})
+}
+
+// importKeystores imports keystores, along with their slashing protection history.
+func (s *Service) importKeystores(w http.ResponseWriter, r *http.Request) {
+	var req importKeystoresRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if len(req.Keystores) != len(req.Passwords) {
+		writeError(w, http.StatusBadRequest, "number of keystores and passwords must match")
+
+		return
+	}
+
+	// Slashing protection history is imported first, so keys are never active without it.
+	if req.SlashingProtection != "" {
+		interchange, err := slashingprotection.ParseInterchange([]byte(req.SlashingProtection), nil)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid slashing protection: %v", err))
+
+			return
+		}
+		if err := s.slashingProtection.Import(interchange); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("failed to import slashing protection: %v", err))
+
+			return
+		}
+	}
+
+	data := make([]*statusJSON, len(req.Keystores))
+	for i := range req.Keystores {
+		data[i] = s.importKeystore(r.Context(), []byte(req.Keystores[i]), req.Passwords[i])
+	}
+	writeJSON(w, http.StatusOK, &dataJSON{Data: data})
+}
+
+// importKeystore imports a single keystore.
+func (s *Service) importKeystore(ctx context.Context, data []byte, passphrase string) *statusJSON {
+	key, err := util.PrivateKeyFromKeystore(data, passphrase)
+	if err != nil {
+		return &statusJSON{Status: statusError, Message: err.Error()}
+	}
+	pubKey := key.PublicKey().Marshal()
+
+	// The keystore has already been parsed successfully, so can only contribute its path here.
+	var keystore util.Keystore
+	_ = json.Unmarshal(data, &keystore)
+	if err := s.store.AddKeystore(ctx, &Keystore{
+		PublicKey:  pubKey,
+		Path:       keystore.Path,
+		PrivateKey: key,
+		Data:       data,
+	}); err != nil {
+		// The store checks for duplicates as it adds the key, so that concurrent imports cannot both succeed.
+		if errors.Is(err, ErrDuplicate) {
+			return &statusJSON{Status: statusDuplicate}
+		}
+
+		return &statusJSON{Status: statusError, Message: err.Error()}
+	}
+
+	return &statusJSON{Status: statusImported}
+}
+
+// deleteKeystores deletes local keys, returning their slashing protection history.
+func (s *Service) deleteKeystores(w http.ResponseWriter, r *http.Request) {
+	pubKeys, ok := readPubKeys(w, r)
+	if !ok {
+		return
+	}
+	keystores, err := s.store.Keystores(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	data := make([]*statusJSON, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		data[i] = s.deleteKeystore(r.Context(), keystores, pubKey)
+	}
+
+	// Export history after deletion, so that it includes anything signed up to the point of deletion.
+	interchange, err := json.Marshal(s.slashingProtection.Export(pubKeys...))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+	writeJSON(w, http.StatusOK, &deleteKeystoresResponse{
+		Data:               data,
+		SlashingProtection: string(interchange),
+	})
+}
+
+// deleteKeystore deletes a single local key.
+func (s *Service) deleteKeystore(ctx context.Context, keystores []*Keystore, pubKey []byte) *statusJSON {
+	for _, keystore := range keystores {
+		if !bytes.Equal(keystore.PublicKey, pubKey) {
+			continue
+		}
+		if keystore.ReadOnly {
+			return &statusJSON{Status: statusError, Message: "key is read-only"}
+		}
+		if err := s.store.DeleteKeystore(ctx, pubKey); err != nil {
+			return &statusJSON{Status: statusError, Message: err.Error()}
+		}
+
+		return &statusJSON{Status: statusDeleted}
+	}
+
+	// Keys that are not present but have slashing protection history are reported as inactive, so that
+	// the caller knows to use the returned history.
+	if len(s.slashingProtection.Export(pubKey).Records) > 0 {
+		return &statusJSON{Status: statusNotActive}
+	}
+
+	return &statusJSON{Status: statusNotFound}
+}
+
+// listRemoteKeys lists the remote keys.
+func (s *Service) listRemoteKeys(w http.ResponseWriter, r *http.Request) {
+	remoteKeys, err := s.store.RemoteKeys(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	data := make([]*remoteKeyJSON, len(remoteKeys))
+	for i := range remoteKeys {
+		data[i] = &remoteKeyJSON{
+			Pubkey:   fmt.Sprintf("%#x", remoteKeys[i].PublicKey),
+			URL:      remoteKeys[i].URL,
+			ReadOnly: remoteKeys[i].ReadOnly,
+		}
+	}
+	writeJSON(w, http.StatusOK, &dataJSON{Data: data})
+}
+
+// importRemoteKeys imports remote keys.
+func (s *Service) importRemoteKeys(w http.ResponseWriter, r *http.Request) {
+	var req importRemoteKeysRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	data := make([]*statusJSON, len(req.RemoteKeys))
+	for i := range req.RemoteKeys {
+		data[i] = s.importRemoteKey(r.Context(), req.RemoteKeys[i])
+	}
+	writeJSON(w, http.StatusOK, &dataJSON{Data: data})
+}
+
+// importRemoteKey imports a single remote key.
+func (s *Service) importRemoteKey(ctx context.Context, remoteKey *remoteKeyJSON) *statusJSON {
+	if remoteKey == nil {
+		return &statusJSON{Status: statusError, Message: "remote key missing"}
+	}
+	pubKey, err := parsePubKey(remoteKey.Pubkey)
+	if err != nil {
+		return &statusJSON{Status: statusError, Message: err.Error()}
+	}
+	signerURL, err := url.Parse(remoteKey.URL)
+	if err != nil || signerURL.Scheme == "" || signerURL.Host == "" {
+		return &statusJSON{Status: statusError, Message: fmt.Sprintf("invalid URL %q", remoteKey.URL)}
+	}
+
+	if err := s.store.AddRemoteKey(ctx, &RemoteKey{
+		PublicKey: pubKey,
+		URL:       remoteKey.URL,
+	}); err != nil {
+		if errors.Is(err, ErrDuplicate) {
+			return &statusJSON{Status: statusDuplicate}
+		}
+
+		return &statusJSON{Status: statusError, Message: err.Error()}
+	}
+
+	return &statusJSON{Status: statusImported}
+}
+
+// deleteRemoteKeys deletes remote keys.
+func (s *Service) deleteRemoteKeys(w http.ResponseWriter, r *http.Request) {
+	pubKeys, ok := readPubKeys(w, r)
+	if !ok {
+		return
+	}
+	remoteKeys, err := s.store.RemoteKeys(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	data := make([]*statusJSON, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		data[i] = &statusJSON{Status: statusNotFound}
+		for _, remoteKey := range remoteKeys {
+			if !bytes.Equal(remoteKey.PublicKey, pubKey) {
+				continue
+			}
+			data[i] = s.deleteRemoteKey(r.Context(), remoteKey)
+
+			break
+		}
+	}
+	writeJSON(w, http.StatusOK, &dataJSON{Data: data})
+}
+
+// deleteRemoteKey deletes a single remote key.
+func (s *Service) deleteRemoteKey(ctx context.Context, remoteKey *RemoteKey) *statusJSON {
+	if remoteKey.ReadOnly {
+		return &statusJSON{Status: statusError, Message: "key is read-only"}
+	}
+	if err := s.store.DeleteRemoteKey(ctx, remoteKey.PublicKey); err != nil {
+		return &statusJSON{Status: statusError, Message: err.Error()}
+	}
+
+	return &statusJSON{Status: statusDeleted}
+}
+
+// getFeeRecipient returns the fee recipient for a key.
+func (s *Service) getFeeRecipient(w http.ResponseWriter, r *http.Request, pubKeyStr string) {
+	pubKey, ok := s.managedPubKey(w, r, pubKeyStr)
+	if !ok {
+		return
+	}
+
+	address, err := s.store.FeeRecipient(r.Context(), pubKey)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		if s.defaultFeeRecipient == nil {
+			writeError(w, http.StatusNotFound, "no fee recipient set")
+
+			return
+		}
+		address = s.defaultFeeRecipient
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &dataJSON{Data: &feeRecipientJSON{
+		Pubkey:     fmt.Sprintf("%#x", pubKey),
+		EthAddress: fmt.Sprintf("%#x", address),
+	}})
+}
+
+// setFeeRecipient sets the fee recipient for a key.
+func (s *Service) setFeeRecipient(w http.ResponseWriter, r *http.Request, pubKeyStr string) {
+	pubKey, ok := s.managedPubKey(w, r, pubKeyStr)
+	if !ok {
+		return
+	}
+	var req feeRecipientJSON
+	if !readJSON(w, r, &req) {
+		return
+	}
+	address, err := hex.DecodeString(strings.TrimPrefix(req.EthAddress, "0x"))
+	if err != nil || len(address) != 20 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid address %q", req.EthAddress))
+
+		return
+	}
+
+	if err := s.store.SetFeeRecipient(r.Context(), pubKey, address); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// deleteFeeRecipient deletes the fee recipient for a key, so that it uses the default.
+func (s *Service) deleteFeeRecipient(w http.ResponseWriter, r *http.Request, pubKeyStr string) {
+	pubKey, ok := s.managedPubKey(w, r, pubKeyStr)
+	if !ok {
+		return
+	}
+
+	if err := s.store.DeleteFeeRecipient(r.Context(), pubKey); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readPubKeys reads the public keys from a delete request, writing an error response if they are not valid.
+func readPubKeys(w http.ResponseWriter, r *http.Request) ([][]byte, bool) {
+	var req deleteRequest
+	if !readJSON(w, r, &req) {
+		return nil, false
+	}
+	pubKeys := make([][]byte, len(req.Pubkeys))
+	for i := range req.Pubkeys {
+		var err error
+		pubKeys[i], err = parsePubKey(req.Pubkeys[i])
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+
+			return nil, false
+		}
+	}
+
+	return pubKeys, true
+}
+
+// managedPubKey parses a public key from a path, writing an error response if it is not valid or not managed.
+func (s *Service) managedPubKey(w http.ResponseWriter, r *http.Request, pubKeyStr string) ([]byte, bool) {
+	pubKey, err := parsePubKey(pubKeyStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return nil, false
+	}
+	managed, err := s.managed(r.Context(), pubKey)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return nil, false
+	}
+	if !managed {
+		writeError(w, http.StatusNotFound, "public key not found")
+
+		return nil, false
+	}
+
+	return pubKey, true
+}
+
+// managed returns true if the public key is held as either a local or a remote key.
+func (s *Service) managed(ctx context.Context, pubKey []byte) (bool, error) {
+	keystores, err := s.store.Keystores(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, keystore := range keystores {
+		if bytes.Equal(keystore.PublicKey, pubKey) {
+			return true, nil
+		}
+	}
+	remoteKeys, err := s.store.RemoteKeys(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, remoteKey := range remoteKeys {
+		if bytes.Equal(remoteKey.PublicKey, pubKey) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,90 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanager
+
+import (
+	"github.com/pkg/errors"
+)
+
+type parameters struct {
+	store               Store
+	slashingProtection  SlashingProtection
+	token               string
+	defaultFeeRecipient []byte
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithStore sets the store for keys and fee recipients.
+func WithStore(store Store) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.store = store
+	})
+}
+
+// WithSlashingProtection sets the slashing protection to which imported history is added, and from which
+// the history of deleted keys is exported.
+func WithSlashingProtection(protection SlashingProtection) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.slashingProtection = protection
+	})
+}
+
+// WithToken sets the bearer token required to access the API.
+func WithToken(token string) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.token = token
+	})
+}
+
+// WithDefaultFeeRecipient sets the fee recipient for keys that do not have their own.
+func WithDefaultFeeRecipient(address []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.defaultFeeRecipient = address
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.store == nil {
+		return nil, errors.New("no store specified")
+	}
+	if parameters.slashingProtection == nil {
+		return nil, errors.New("no slashing protection specified")
+	}
+	if parameters.token == "" {
+		return nil, errors.New("no token specified")
+	}
+	if parameters.defaultFeeRecipient != nil && len(parameters.defaultFeeRecipient) != 20 {
+		return nil, errors.New("default fee recipient must be 20 bytes")
+	}
+
+	return &parameters, nil
+}
@@ -0,0 +1,177 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keymanager provides an HTTP server that implements the Ethereum keymanager API.
+package keymanager
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/wealdtech/go-eth2-util/slashingprotection"
+)
+
+const (
+	keystoresPath        = "/eth/v1/keystores"
+	remoteKeysPath       = "/eth/v1/remotekeys"
+	validatorPathPrefix  = "/eth/v1/validator/"
+	feeRecipientPathPart = "/feerecipient"
+
+	// maxRequestSize is the maximum size of a request body.
+	maxRequestSize = 16 * 1024 * 1024
+)
+
+// SlashingProtection imports and exports slashing protection history.
+// It is implemented by the store in the slashingprotection package.
+type SlashingProtection interface {
+	// Import imports slashing protection history.
+	Import(interchange *slashingprotection.Interchange) error
+	// Export exports the slashing protection history of the given public keys.
+	Export(pubKeys ...[]byte) *slashingprotection.Interchange
+}
+
+// Service is a service implementing the Ethereum keymanager API.
+type Service struct {
+	store               Store
+	slashingProtection  SlashingProtection
+	token               string
+	defaultFeeRecipient []byte
+}
+
+// New creates a new keymanager service.
+func New(params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		store:               parameters.store,
+		slashingProtection:  parameters.slashingProtection,
+		token:               parameters.token,
+		defaultFeeRecipient: parameters.defaultFeeRecipient,
+	}, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorize(w, r) {
+		return
+	}
+
+	switch {
+	case r.URL.Path == keystoresPath:
+		switch r.Method {
+		case http.MethodGet:
+			s.listKeystores(w, r)
+		case http.MethodPost:
+			s.importKeystores(w, r)
+		case http.MethodDelete:
+			s.deleteKeystores(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	case r.URL.Path == remoteKeysPath:
+		switch r.Method {
+		case http.MethodGet:
+			s.listRemoteKeys(w, r)
+		case http.MethodPost:
+			s.importRemoteKeys(w, r)
+		case http.MethodDelete:
+			s.deleteRemoteKeys(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	case strings.HasPrefix(r.URL.Path, validatorPathPrefix) && strings.HasSuffix(r.URL.Path, feeRecipientPathPart):
+		pubKey := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, validatorPathPrefix), feeRecipientPathPart)
+		switch r.Method {
+		case http.MethodGet:
+			s.getFeeRecipient(w, r, pubKey)
+		case http.MethodPost:
+			s.setFeeRecipient(w, r, pubKey)
+		case http.MethodDelete:
+			s.deleteFeeRecipient(w, r, pubKey)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// authorize checks the bearer token of a request, writing an error response if it is not valid.
+func (s *Service) authorize(w http.ResponseWriter, r *http.Request) bool {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found {
+		writeError(w, http.StatusUnauthorized, "bearer token required")
+
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		writeError(w, http.StatusForbidden, "invalid token")
+
+		return false
+	}
+
+	return true
+}
+
+// readJSON reads a JSON request body, writing an error response if it is not valid.
+func readJSON(w http.ResponseWriter, r *http.Request, val any) bool {
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestSize)).Decode(val); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+
+		return false
+	}
+
+	return true
+}
+
+// writeJSON writes a value as a JSON response.
+func writeJSON(w http.ResponseWriter, status int, val any) {
+	data, err := json.Marshal(val)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// writeError writes an error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	data, _ := json.Marshal(&errorJSON{Message: message})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// parsePubKey parses a hex public key.
+func parsePubKey(input string) ([]byte, error) {
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key %q", input)
+	}
+	if len(pubKey) != 48 {
+		return nil, fmt.Errorf("invalid public key %q", input)
+	}
+
+	return pubKey, nil
+}
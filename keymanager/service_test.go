@@ -0,0 +1,313 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanager_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	"github.com/wealdtech/go-eth2-util/keymanager"
+	"github.com/wealdtech/go-eth2-util/slashingprotection"
+)
+
+func TestMain(m *testing.M) {
+	if err := e2types.InitBLS(); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+const (
+	testToken  = "secret"
+	testGVR    = "04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	testSeed   = "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	testPubKey = "0xb3d758f5ff8d1bdfe4b744e2372b5f37261619f4a45c97db829675e4669732781c858caaec6dbe86c2d096070de5c992"
+	// testKeystore is the PBKDF2 test keystore from EIP-2335.
+	testKeystore = `{"crypto":{"kdf":{"function":"pbkdf2","params":{"dklen":32,"c":262144,"prf":"hmac-sha256",` +
+		`"salt":"d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"},"message":""},` +
+		`"checksum":{"function":"sha256","params":{},"message":"8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"},` +
+		`"cipher":{"function":"aes-128-ctr","params":{"iv":"264daa3f303d7259501c93d997d84fe6"},` +
+		`"message":"cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"}},` +
+		`"pubkey":"9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",` +
+		`"path":"m/12381/60/0/0","uuid":"64625def-3331-4eea-ab6f-782f3ed16a83","version":4}`
+	testKeystorePassphrase = "testpassword\U0001F511"
+	// testKeystoreUnicodePassphrase is the passphrase as given in EIP-2335, which NFKD-normalises to the above.
+	testKeystoreUnicodePassphrase = "𝔱𝔢𝔰𝔱𝔭𝔞𝔰𝔰𝔴𝔬𝔯𝔡🔑"
+	testKeystorePubKey            = "0x9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07"
+	testRemotePubKey              = "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+)
+
+func _byteArray(input string) []byte {
+	res, _ := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	return res
+}
+
+func testService(t *testing.T) (*keymanager.Service, *slashingprotection.Store) {
+	t.Helper()
+
+	keystores, err := keymanager.DerivedKeystores(_byteArray(testSeed), "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	protection, err := slashingprotection.NewStore(filepath.Join(t.TempDir(), "protection.json"), _byteArray(testGVR))
+	require.NoError(t, err)
+	service, err := keymanager.New(
+		keymanager.WithStore(keymanager.NewMemoryStore(keystores...)),
+		keymanager.WithSlashingProtection(protection),
+		keymanager.WithToken(testToken),
+		keymanager.WithDefaultFeeRecipient(_byteArray("0x000102030405060708090a0b0c0d0e0f10111213")),
+	)
+	require.NoError(t, err)
+
+	return service, protection
+}
+
+func request(service http.Handler, method string, path string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	service.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestNew(t *testing.T) {
+	store := keymanager.NewMemoryStore()
+	protection, err := slashingprotection.NewStore(filepath.Join(t.TempDir(), "protection.json"), _byteArray(testGVR))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params []keymanager.Parameter
+		err    string
+	}{
+		{
+			name: "StoreMissing",
+			params: []keymanager.Parameter{
+				keymanager.WithSlashingProtection(protection),
+				keymanager.WithToken(testToken),
+			},
+			err: "no store specified",
+		},
+		{
+			name: "SlashingProtectionMissing",
+			params: []keymanager.Parameter{
+				keymanager.WithStore(store),
+				keymanager.WithToken(testToken),
+			},
+			err: "no slashing protection specified",
+		},
+		{
+			name: "TokenMissing",
+			params: []keymanager.Parameter{
+				keymanager.WithStore(store),
+				keymanager.WithSlashingProtection(protection),
+			},
+			err: "no token specified",
+		},
+		{
+			name: "DefaultFeeRecipientInvalid",
+			params: []keymanager.Parameter{
+				keymanager.WithStore(store),
+				keymanager.WithSlashingProtection(protection),
+				keymanager.WithToken(testToken),
+				keymanager.WithDefaultFeeRecipient([]byte{0x01}),
+			},
+			err: "default fee recipient must be 20 bytes",
+		},
+		{
+			name: "Good",
+			params: []keymanager.Parameter{
+				keymanager.WithStore(store),
+				keymanager.WithSlashingProtection(protection),
+				keymanager.WithToken(testToken),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := keymanager.New(test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	service, _ := testService(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/eth/v1/keystores", nil)
+	rec := httptest.NewRecorder()
+	service.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req.Header.Set("Authorization", "Bearer wrong")
+	rec = httptest.NewRecorder()
+	service.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, `{"message":"invalid token"}`, rec.Body.String())
+
+	rec = request(service, http.MethodGet, "/eth/v1/unknown", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestKeystores(t *testing.T) {
+	service, protection := testService(t)
+
+	rec := request(service, http.MethodGet, "/eth/v1/keystores", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"data":[{"validating_pubkey":"`+testPubKey+`","derivation_path":"m/12381/3600/0/0/0","readonly":true}]}`,
+		rec.Body.String())
+
+	// Import a keystore with its slashing protection history, alongside a duplicate and an invalid keystore.
+	history := fmt.Sprintf(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x%s"},`+
+		`"data":[{"pubkey":"%s","signed_blocks":[{"slot":"100"}],"signed_attestations":[]}]}`, testGVR, testKeystorePubKey)
+	body, err := json.Marshal(map[string]any{
+		"keystores":           []string{testKeystore, testKeystore, "bad"},
+		"passwords":           []string{testKeystorePassphrase, testKeystorePassphrase, ""},
+		"slashing_protection": history,
+	})
+	require.NoError(t, err)
+	rec = request(service, http.MethodPost, "/eth/v1/keystores", string(body))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"data":[{"status":"imported"},{"status":"duplicate"},`+
+		`{"status":"error","message":"invalid keystore: invalid character 'b' looking for beginning of value"}]}`,
+		rec.Body.String())
+	require.EqualError(t, protection.CheckAndRecordBlock(_byteArray(testKeystorePubKey), 99, make([]byte, 32)),
+		"slashable: slot 99 is before signed block at slot 100")
+
+	rec = request(service, http.MethodGet, "/eth/v1/keystores", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `{"validating_pubkey":"`+testKeystorePubKey+`","derivation_path":"m/12381/60/0/0","readonly":false}`)
+
+	rec = request(service, http.MethodPost, "/eth/v1/keystores", `{"keystores":["a"],"passwords":[]}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `{"message":"number of keystores and passwords must match"}`, rec.Body.String())
+
+	rec = request(service, http.MethodPost, "/eth/v1/keystores",
+		`{"keystores":[],"passwords":[],"slashing_protection":"{\"metadata\":{\"interchange_format_version\":\"5\",`+
+			`\"genesis_validators_root\":\"0x`+strings.Repeat("00", 32)+`\"},\"data\":[]}"}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "failed to import slashing protection")
+
+	// Delete the imported key, the read-only derived key, a key only known to slashing protection and an unknown key.
+	require.NoError(t, protection.CheckAndRecordBlock(_byteArray(testRemotePubKey), 5, make([]byte, 32)))
+	rec = request(service, http.MethodDelete, "/eth/v1/keystores",
+		`{"pubkeys":["`+testKeystorePubKey+`","`+testPubKey+`","`+testRemotePubKey+`","0x`+strings.Repeat("00", 48)+`"]}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var res struct {
+		Data []struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		} `json:"data"`
+		SlashingProtection string `json:"slashing_protection"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Len(t, res.Data, 4)
+	assert.Equal(t, "deleted", res.Data[0].Status)
+	assert.Equal(t, "error", res.Data[1].Status)
+	assert.Equal(t, "key is read-only", res.Data[1].Message)
+	assert.Equal(t, "not_active", res.Data[2].Status)
+	assert.Equal(t, "not_found", res.Data[3].Status)
+	interchange, err := slashingprotection.ParseInterchange([]byte(res.SlashingProtection), _byteArray(testGVR))
+	require.NoError(t, err)
+	assert.Len(t, interchange.Records, 2)
+
+	rec = request(service, http.MethodDelete, "/eth/v1/keystores", `{"pubkeys":["0x01"]}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `{"message":"invalid public key \"0x01\""}`, rec.Body.String())
+}
+
+func TestKeystoresUnicodePassphrase(t *testing.T) {
+	service, _ := testService(t)
+
+	// Passphrases are NFKD-normalised before use, so the passphrase as given in the EIP decrypts the keystore.
+	body, err := json.Marshal(map[string]any{
+		"keystores": []string{testKeystore, testKeystore},
+		"passwords": []string{"\U0001D531\U0001D522\U0001D530\U0001D531", testKeystoreUnicodePassphrase},
+	})
+	require.NoError(t, err)
+	rec := request(service, http.MethodPost, "/eth/v1/keystores", string(body))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"data":[{"status":"error","message":"invalid passphrase"},{"status":"imported"}]}`, rec.Body.String())
+}
+
+func TestRemoteKeys(t *testing.T) {
+	service, _ := testService(t)
+
+	rec := request(service, http.MethodGet, "/eth/v1/remotekeys", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"data":[]}`, rec.Body.String())
+
+	rec = request(service, http.MethodPost, "/eth/v1/remotekeys", `{"remote_keys":[`+
+		`{"pubkey":"`+testRemotePubKey+`","url":"https://signer.example.com"},`+
+		`{"pubkey":"`+testRemotePubKey+`","url":"https://signer.example.com"},`+
+		`{"pubkey":"`+testPubKey+`","url":"https://signer.example.com"},`+
+		`{"pubkey":"0x`+strings.Repeat("00", 48)+`","url":"signer"}]}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"data":[{"status":"imported"},{"status":"duplicate"},{"status":"duplicate"},`+
+		`{"status":"error","message":"invalid URL \"signer\""}]}`, rec.Body.String())
+
+	rec = request(service, http.MethodGet, "/eth/v1/remotekeys", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"data":[{"pubkey":"`+testRemotePubKey+`","url":"https://signer.example.com","readonly":false}]}`,
+		rec.Body.String())
+
+	rec = request(service, http.MethodDelete, "/eth/v1/remotekeys", `{"pubkeys":["`+testRemotePubKey+`","`+testPubKey+`"]}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"data":[{"status":"deleted"},{"status":"not_found"}]}`, rec.Body.String())
+}
+
+func TestFeeRecipient(t *testing.T) {
+	service, _ := testService(t)
+	path := "/eth/v1/validator/" + testPubKey + "/feerecipient"
+
+	rec := request(service, http.MethodGet, path, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"data":{"pubkey":"`+testPubKey+`","ethaddress":"0x000102030405060708090a0b0c0d0e0f10111213"}}`,
+		rec.Body.String())
+
+	rec = request(service, http.MethodPost, path, `{"ethaddress":"0xabcdef0102030405060708090a0b0c0d0e0f1011"}`)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	rec = request(service, http.MethodGet, path, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"data":{"pubkey":"`+testPubKey+`","ethaddress":"0xabcdef0102030405060708090a0b0c0d0e0f1011"}}`,
+		rec.Body.String())
+
+	rec = request(service, http.MethodPost, path, `{"ethaddress":"0x01"}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `{"message":"invalid address \"0x01\""}`, rec.Body.String())
+
+	rec = request(service, http.MethodDelete, path, "")
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	rec = request(service, http.MethodGet, path, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "0x000102030405060708090a0b0c0d0e0f10111213")
+
+	rec = request(service, http.MethodGet, "/eth/v1/validator/"+testRemotePubKey+"/feerecipient", "")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, `{"message":"public key not found"}`, rec.Body.String())
+}
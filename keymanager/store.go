@@ -0,0 +1,243 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanager
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+// ErrNotFound is returned by stores when a key is not present.
+var ErrNotFound = errors.New("not found")
+
+// ErrDuplicate is returned by stores when adding a key that is already present as either a local or a remote key.
+var ErrDuplicate = errors.New("duplicate")
+
+// Keystore is a local key managed by the service.
+type Keystore struct {
+	PublicKey []byte
+	// Path is the derivation path of the key, if known.
+	Path string
+	// PrivateKey is the private key.
+	PrivateKey *e2types.BLSPrivateKey
+	// Data is the EIP-2335 keystore from which the key was imported; it is empty for derived keys.
+	Data []byte
+	// ReadOnly keys cannot be deleted through the API.
+	ReadOnly bool
+}
+
+// RemoteKey is a key held by a remote signer.
+type RemoteKey struct {
+	PublicKey []byte
+	// URL is the URL of the remote signer.
+	URL string
+	// ReadOnly keys cannot be deleted through the API.
+	ReadOnly bool
+}
+
+// Store stores the keys and fee recipients managed by the service.
+type Store interface {
+	// Keystores returns all local keys.
+	Keystores(ctx context.Context) ([]*Keystore, error)
+	// AddKeystore adds a local key, returning ErrDuplicate if it is already present as a local or remote key.
+	// The check and the addition must be atomic, so that concurrent imports cannot both add the same key.
+	AddKeystore(ctx context.Context, keystore *Keystore) error
+	// DeleteKeystore deletes a local key, returning ErrNotFound if it is not present.
+	DeleteKeystore(ctx context.Context, pubKey []byte) error
+	// RemoteKeys returns all remote keys.
+	RemoteKeys(ctx context.Context) ([]*RemoteKey, error)
+	// AddRemoteKey adds a remote key, returning ErrDuplicate if it is already present as a local or remote key.
+	// The check and the addition must be atomic, so that concurrent imports cannot both add the same key.
+	AddRemoteKey(ctx context.Context, key *RemoteKey) error
+	// DeleteRemoteKey deletes a remote key, returning ErrNotFound if it is not present.
+	DeleteRemoteKey(ctx context.Context, pubKey []byte) error
+	// FeeRecipient returns the fee recipient for a key, returning ErrNotFound if it is not set.
+	FeeRecipient(ctx context.Context, pubKey []byte) ([]byte, error)
+	// SetFeeRecipient sets the fee recipient for a key.
+	SetFeeRecipient(ctx context.Context, pubKey []byte, address []byte) error
+	// DeleteFeeRecipient deletes the fee recipient for a key.
+	DeleteFeeRecipient(ctx context.Context, pubKey []byte) error
+}
+
+// DerivedKeystores derives read-only keystores from a seed at the given paths.
+// Paths can be path templates such as "m/12381/3600/{0..99}/0/0", as accepted by util.NewPathIterator.
+func DerivedKeystores(seed []byte, paths ...string) ([]*Keystore, error) {
+	deriver, err := util.NewKeyDeriver(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Keystore, 0, len(paths))
+	for _, path := range paths {
+		keys, err := deriver.PrivateKeys(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to derive key at %s", path)
+		}
+		for _, key := range keys {
+			res = append(res, &Keystore{
+				PublicKey:  key.PrivateKey.PublicKey().Marshal(),
+				Path:       key.Path,
+				PrivateKey: key.PrivateKey,
+				ReadOnly:   true,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+// MemoryStore is an in-memory store.
+type MemoryStore struct {
+	mutex         sync.RWMutex
+	keystores     []*Keystore
+	remoteKeys    []*RemoteKey
+	feeRecipients map[string][]byte
+}
+
+// NewMemoryStore creates a new in-memory store holding the given keystores.
+func NewMemoryStore(keystores ...*Keystore) *MemoryStore {
+	return &MemoryStore{
+		keystores:     append([]*Keystore{}, keystores...),
+		feeRecipients: make(map[string][]byte),
+	}
+}
+
+// Keystores returns all local keys.
+func (s *MemoryStore) Keystores(_ context.Context) ([]*Keystore, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return append([]*Keystore{}, s.keystores...), nil
+}
+
+// AddKeystore adds a local key, returning ErrDuplicate if it is already present as a local or remote key.
+func (s *MemoryStore) AddKeystore(_ context.Context, keystore *Keystore) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.present(keystore.PublicKey) {
+		return ErrDuplicate
+	}
+	s.keystores = append(s.keystores, keystore)
+
+	return nil
+}
+
+// DeleteKeystore deletes a local key, returning ErrNotFound if it is not present.
+func (s *MemoryStore) DeleteKeystore(_ context.Context, pubKey []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i := range s.keystores {
+		if bytes.Equal(s.keystores[i].PublicKey, pubKey) {
+			s.keystores = append(s.keystores[:i], s.keystores[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return ErrNotFound
+}
+
+// present returns true if the public key is held as either a local or a remote key.
+// The caller must hold the mutex.
+func (s *MemoryStore) present(pubKey []byte) bool {
+	for _, keystore := range s.keystores {
+		if bytes.Equal(keystore.PublicKey, pubKey) {
+			return true
+		}
+	}
+	for _, remoteKey := range s.remoteKeys {
+		if bytes.Equal(remoteKey.PublicKey, pubKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoteKeys returns all remote keys.
+func (s *MemoryStore) RemoteKeys(_ context.Context) ([]*RemoteKey, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return append([]*RemoteKey{}, s.remoteKeys...), nil
+}
+
+// AddRemoteKey adds a remote key, returning ErrDuplicate if it is already present as a local or remote key.
+func (s *MemoryStore) AddRemoteKey(_ context.Context, key *RemoteKey) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.present(key.PublicKey) {
+		return ErrDuplicate
+	}
+	s.remoteKeys = append(s.remoteKeys, key)
+
+	return nil
+}
+
+// DeleteRemoteKey deletes a remote key, returning ErrNotFound if it is not present.
+func (s *MemoryStore) DeleteRemoteKey(_ context.Context, pubKey []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i := range s.remoteKeys {
+		if bytes.Equal(s.remoteKeys[i].PublicKey, pubKey) {
+			s.remoteKeys = append(s.remoteKeys[:i], s.remoteKeys[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return ErrNotFound
+}
+
+// FeeRecipient returns the fee recipient for a key, returning ErrNotFound if it is not set.
+func (s *MemoryStore) FeeRecipient(_ context.Context, pubKey []byte) ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	address, exists := s.feeRecipients[string(pubKey)]
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	return address, nil
+}
+
+// SetFeeRecipient sets the fee recipient for a key.
+func (s *MemoryStore) SetFeeRecipient(_ context.Context, pubKey []byte, address []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.feeRecipients[string(pubKey)] = address
+
+	return nil
+}
+
+// DeleteFeeRecipient deletes the fee recipient for a key.
+func (s *MemoryStore) DeleteFeeRecipient(_ context.Context, pubKey []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.feeRecipients, string(pubKey))
+
+	return nil
+}
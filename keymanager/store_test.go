@@ -0,0 +1,105 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanager_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/go-eth2-util/keymanager"
+)
+
+func TestDerivedKeystores(t *testing.T) {
+	_, err := keymanager.DerivedKeystores(_byteArray(testSeed), "bad")
+	require.EqualError(t, err, "failed to derive key at bad: not master at path component 0")
+
+	keystores, err := keymanager.DerivedKeystores(_byteArray(testSeed), "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	require.Len(t, keystores, 1)
+	assert.Equal(t, _byteArray(testPubKey), keystores[0].PublicKey)
+	assert.Equal(t, "m/12381/3600/0/0/0", keystores[0].Path)
+	assert.True(t, keystores[0].ReadOnly)
+
+	keystores, err = keymanager.DerivedKeystores(_byteArray(testSeed), "m/12381/3600/{0..2}/0/0", "m/12381/3600/5/0/0")
+	require.NoError(t, err)
+	require.Len(t, keystores, 4)
+	assert.Equal(t, _byteArray(testPubKey), keystores[0].PublicKey)
+	assert.Equal(t, "m/12381/3600/2/0/0", keystores[2].Path)
+	assert.Equal(t, "m/12381/3600/5/0/0", keystores[3].Path)
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := keymanager.NewMemoryStore()
+
+	require.NoError(t, store.AddKeystore(ctx, &keymanager.Keystore{PublicKey: _byteArray(testPubKey)}))
+	require.ErrorIs(t, store.AddKeystore(ctx, &keymanager.Keystore{PublicKey: _byteArray(testPubKey)}), keymanager.ErrDuplicate)
+	require.ErrorIs(t, store.AddRemoteKey(ctx, &keymanager.RemoteKey{PublicKey: _byteArray(testPubKey)}), keymanager.ErrDuplicate)
+	keystores, err := store.Keystores(ctx)
+	require.NoError(t, err)
+	require.Len(t, keystores, 1)
+	require.NoError(t, store.DeleteKeystore(ctx, _byteArray(testPubKey)))
+	require.ErrorIs(t, store.DeleteKeystore(ctx, _byteArray(testPubKey)), keymanager.ErrNotFound)
+
+	require.NoError(t, store.AddRemoteKey(ctx, &keymanager.RemoteKey{PublicKey: _byteArray(testRemotePubKey)}))
+	require.ErrorIs(t, store.AddRemoteKey(ctx, &keymanager.RemoteKey{PublicKey: _byteArray(testRemotePubKey)}), keymanager.ErrDuplicate)
+	require.ErrorIs(t, store.AddKeystore(ctx, &keymanager.Keystore{PublicKey: _byteArray(testRemotePubKey)}), keymanager.ErrDuplicate)
+	remoteKeys, err := store.RemoteKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, remoteKeys, 1)
+	require.NoError(t, store.DeleteRemoteKey(ctx, _byteArray(testRemotePubKey)))
+	require.ErrorIs(t, store.DeleteRemoteKey(ctx, _byteArray(testRemotePubKey)), keymanager.ErrNotFound)
+
+	_, err = store.FeeRecipient(ctx, _byteArray(testPubKey))
+	require.ErrorIs(t, err, keymanager.ErrNotFound)
+	require.NoError(t, store.SetFeeRecipient(ctx, _byteArray(testPubKey), []byte{0x01}))
+	address, err := store.FeeRecipient(ctx, _byteArray(testPubKey))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01}, address)
+	require.NoError(t, store.DeleteFeeRecipient(ctx, _byteArray(testPubKey)))
+	_, err = store.FeeRecipient(ctx, _byteArray(testPubKey))
+	require.ErrorIs(t, err, keymanager.ErrNotFound)
+}
+
+func TestMemoryStoreConcurrentAdd(t *testing.T) {
+	ctx := context.Background()
+	store := keymanager.NewMemoryStore()
+
+	// Only one of many concurrent additions of the same key succeeds.
+	var wg sync.WaitGroup
+	var added atomic.Int32
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			if i%2 == 0 {
+				err = store.AddKeystore(ctx, &keymanager.Keystore{PublicKey: _byteArray(testPubKey)})
+			} else {
+				err = store.AddRemoteKey(ctx, &keymanager.RemoteKey{PublicKey: _byteArray(testPubKey)})
+			}
+			if err == nil {
+				added.Add(1)
+			} else {
+				assert.ErrorIs(t, err, keymanager.ErrDuplicate)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), added.Load())
+}
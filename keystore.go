@@ -0,0 +1,225 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Limits on keystore KDF parameters, which bound the memory and time taken to decrypt an untrusted keystore.
+// They allow the parameters used by the EIP's test vectors and common tools.
+const (
+	maxKeystoreDKLen   = 64
+	maxKeystoreScryptN = 1 << 18
+	maxKeystoreScryptR = 8
+	maxKeystoreScryptP = 16
+	maxKeystorePBKDF2C = 1 << 22
+)
+
+// Keystore is an EIP-2335 keystore.
+type Keystore struct {
+	Crypto      *KeystoreCrypto `json:"crypto"`
+	Description string          `json:"description,omitempty"`
+	PublicKey   string          `json:"pubkey"`
+	Path        string          `json:"path"`
+	UUID        string          `json:"uuid"`
+	Version     uint            `json:"version"`
+}
+
+// KeystoreCrypto is the crypto section of an EIP-2335 keystore.
+type KeystoreCrypto struct {
+	KDF      *KeystoreModule `json:"kdf"`
+	Checksum *KeystoreModule `json:"checksum"`
+	Cipher   *KeystoreModule `json:"cipher"`
+}
+
+// KeystoreModule is a module of the crypto section of an EIP-2335 keystore.
+type KeystoreModule struct {
+	Function string                     `json:"function"`
+	Params   map[string]json.RawMessage `json:"params"`
+	Message  string                     `json:"message"`
+}
+
+// PrivateKeyFromKeystore decrypts an EIP-2335 keystore to obtain its private key.
+// Keystores with KDF parameters that would take excessive memory or time to derive the decryption key are rejected.
+// The passphrase is NFKD-normalised and has control characters removed as per the EIP.
+func PrivateKeyFromKeystore(data []byte, passphrase string) (*e2types.BLSPrivateKey, error) {
+	var keystore Keystore
+	if err := json.Unmarshal(data, &keystore); err != nil {
+		return nil, errors.Wrap(err, "invalid keystore")
+	}
+	if keystore.Version != 4 {
+		return nil, fmt.Errorf("unsupported keystore version %d", keystore.Version)
+	}
+	if keystore.Crypto == nil || keystore.Crypto.KDF == nil || keystore.Crypto.Checksum == nil || keystore.Crypto.Cipher == nil {
+		return nil, errors.New("keystore crypto incomplete")
+	}
+
+	decryptionKey, err := keystoreDecryptionKey(keystore.Crypto.KDF, keystorePassphrase(passphrase))
+	if err != nil {
+		return nil, err
+	}
+	cipherMessage, err := hex.DecodeString(keystore.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cipher message")
+	}
+
+	if keystore.Crypto.Checksum.Function != "sha256" {
+		return nil, fmt.Errorf("unsupported checksum function %q", keystore.Crypto.Checksum.Function)
+	}
+	checksum, err := hex.DecodeString(keystore.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid checksum message")
+	}
+	if !bytes.Equal(SHA256(decryptionKey[16:32], cipherMessage), checksum) {
+		return nil, errors.New("invalid passphrase")
+	}
+
+	if keystore.Crypto.Cipher.Function != "aes-128-ctr" {
+		return nil, fmt.Errorf("unsupported cipher function %q", keystore.Crypto.Cipher.Function)
+	}
+	var ivHex string
+	if err := keystoreParam(keystore.Crypto.Cipher, "iv", &ivHex); err != nil {
+		return nil, err
+	}
+	iv, err := hex.DecodeString(ivHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid iv")
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, errors.New("invalid iv")
+	}
+	block, err := aes.NewCipher(decryptionKey[:16])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	secret := make([]byte, len(cipherMessage))
+	cipher.NewCTR(block, iv).XORKeyStream(secret, cipherMessage)
+
+	key, err := e2types.BLSPrivateKeyFromBytes(secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid private key")
+	}
+	if keystore.PublicKey != "" && strings.TrimPrefix(keystore.PublicKey, "0x") != hex.EncodeToString(key.PublicKey().Marshal()) {
+		return nil, errors.New("private key does not match keystore public key")
+	}
+
+	return key, nil
+}
+
+// keystoreDecryptionKey derives the decryption key from the passphrase with the keystore's KDF.
+func keystoreDecryptionKey(kdf *KeystoreModule, passphrase []byte) ([]byte, error) {
+	var saltHex string
+	if err := keystoreParam(kdf, "salt", &saltHex); err != nil {
+		return nil, err
+	}
+	salt, err := hex.DecodeString(saltHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid salt")
+	}
+	var dkLen int
+	if err := keystoreParam(kdf, "dklen", &dkLen); err != nil {
+		return nil, err
+	}
+	if dkLen < 32 || dkLen > maxKeystoreDKLen {
+		return nil, fmt.Errorf("derived key length must be between 32 and %d", maxKeystoreDKLen)
+	}
+
+	switch kdf.Function {
+	case "scrypt":
+		var n, r, p int
+		if err := keystoreParam(kdf, "n", &n); err != nil {
+			return nil, err
+		}
+		if err := keystoreParam(kdf, "r", &r); err != nil {
+			return nil, err
+		}
+		if err := keystoreParam(kdf, "p", &p); err != nil {
+			return nil, err
+		}
+		if n < 2 || n > maxKeystoreScryptN || n&(n-1) != 0 {
+			return nil, fmt.Errorf("scrypt parameter n must be a power of 2 no greater than %d", maxKeystoreScryptN)
+		}
+		if r < 1 || r > maxKeystoreScryptR {
+			return nil, fmt.Errorf("scrypt parameter r must be between 1 and %d", maxKeystoreScryptR)
+		}
+		if p < 1 || p > maxKeystoreScryptP {
+			return nil, fmt.Errorf("scrypt parameter p must be between 1 and %d", maxKeystoreScryptP)
+		}
+		key, err := scrypt.Key(passphrase, salt, n, r, p, dkLen)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to derive key")
+		}
+
+		return key, nil
+	case "pbkdf2":
+		var c int
+		if err := keystoreParam(kdf, "c", &c); err != nil {
+			return nil, err
+		}
+		var prf string
+		if err := keystoreParam(kdf, "prf", &prf); err != nil {
+			return nil, err
+		}
+		if prf != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported pseudorandom function %q", prf)
+		}
+		if c < 1 || c > maxKeystorePBKDF2C {
+			return nil, fmt.Errorf("pbkdf2 parameter c must be between 1 and %d", maxKeystorePBKDF2C)
+		}
+
+		return pbkdf2.Key(passphrase, salt, c, dkLen, sha256.New), nil
+	default:
+		return nil, fmt.Errorf("unsupported KDF function %q", kdf.Function)
+	}
+}
+
+// keystoreParam decodes a parameter of a keystore module.
+func keystoreParam(module *KeystoreModule, name string, val any) error {
+	param, exists := module.Params[name]
+	if !exists {
+		return fmt.Errorf("%s parameter %s missing", module.Function, name)
+	}
+	if err := json.Unmarshal(param, val); err != nil {
+		return errors.Wrapf(err, "invalid %s parameter %s", module.Function, name)
+	}
+
+	return nil
+}
+
+// keystorePassphrase NFKD-normalises a passphrase and then removes control characters, as per EIP-2335.
+func keystorePassphrase(passphrase string) []byte {
+	return []byte(strings.Map(func(r rune) rune {
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			return -1
+		}
+
+		return r
+	}, norm.NFKD.String(passphrase)))
+}
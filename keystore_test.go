@@ -0,0 +1,166 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+// The EIP-2335 test vectors in testdata, with the passphrase given in the EIP and its NFKD-normalised form.
+const (
+	keystoreUnicodePassphrase = "𝔱𝔢𝔰𝔱𝔭𝔞𝔰𝔰𝔴𝔬𝔯𝔡🔑"
+	keystorePassphrase        = "testpassword\U0001F511"
+	keystoreSecret            = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+)
+
+// keystoreVector returns the contents of an EIP-2335 test vector in testdata.
+func keystoreVector(t *testing.T, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestPrivateKeyFromKeystore(t *testing.T) {
+	keystoreScrypt := keystoreVector(t, "eip2335_scrypt.json")
+	keystorePBKDF2 := keystoreVector(t, "eip2335_pbkdf2.json")
+
+	tests := []struct {
+		name       string
+		keystore   string
+		passphrase string
+		err        string
+	}{
+		{
+			name:       "Invalid",
+			keystore:   "bad",
+			passphrase: keystorePassphrase,
+			err:        "invalid keystore: invalid character 'b' looking for beginning of value",
+		},
+		{
+			name:       "VersionUnsupported",
+			keystore:   strings.Replace(keystorePBKDF2, `"version": 4`, `"version": 3`, 1),
+			passphrase: keystorePassphrase,
+			err:        "unsupported keystore version 3",
+		},
+		{
+			name:       "KDFUnsupported",
+			keystore:   strings.Replace(keystorePBKDF2, `"function": "pbkdf2"`, `"function": "argon2"`, 1),
+			passphrase: keystorePassphrase,
+			err:        `unsupported KDF function "argon2"`,
+		},
+		{
+			name:       "ParamMissing",
+			keystore:   strings.Replace(keystorePBKDF2, `"c": 262144,`, ``, 1),
+			passphrase: keystorePassphrase,
+			err:        "pbkdf2 parameter c missing",
+		},
+		{
+			name:       "DKLenTooLarge",
+			keystore:   strings.Replace(keystorePBKDF2, `"dklen": 32`, `"dklen": 65`, 1),
+			passphrase: keystorePassphrase,
+			err:        "derived key length must be between 32 and 64",
+		},
+		{
+			name:       "PBKDF2CTooLarge",
+			keystore:   strings.Replace(keystorePBKDF2, `"c": 262144`, `"c": 4194305`, 1),
+			passphrase: keystorePassphrase,
+			err:        "pbkdf2 parameter c must be between 1 and 4194304",
+		},
+		{
+			name:       "ScryptNTooLarge",
+			keystore:   strings.Replace(keystoreScrypt, `"n": 262144`, `"n": 524288`, 1),
+			passphrase: keystorePassphrase,
+			err:        "scrypt parameter n must be a power of 2 no greater than 262144",
+		},
+		{
+			name:       "ScryptNNotPowerOfTwo",
+			keystore:   strings.Replace(keystoreScrypt, `"n": 262144`, `"n": 262143`, 1),
+			passphrase: keystorePassphrase,
+			err:        "scrypt parameter n must be a power of 2 no greater than 262144",
+		},
+		{
+			name:       "ScryptRTooLarge",
+			keystore:   strings.Replace(keystoreScrypt, `"r": 8`, `"r": 9`, 1),
+			passphrase: keystorePassphrase,
+			err:        "scrypt parameter r must be between 1 and 8",
+		},
+		{
+			name:       "ScryptPTooLarge",
+			keystore:   strings.Replace(keystoreScrypt, `"p": 1`, `"p": 17`, 1),
+			passphrase: keystorePassphrase,
+			err:        "scrypt parameter p must be between 1 and 16",
+		},
+		{
+			name:       "PassphraseIncorrect",
+			keystore:   keystorePBKDF2,
+			passphrase: "wrong",
+			err:        "invalid passphrase",
+		},
+		{
+			name:       "PublicKeyMismatch",
+			keystore:   strings.Replace(keystorePBKDF2, `"pubkey": "96`, `"pubkey": "97`, 1),
+			passphrase: keystorePassphrase,
+			err:        "private key does not match keystore public key",
+		},
+		{
+			name:       "PBKDF2",
+			keystore:   keystorePBKDF2,
+			passphrase: keystorePassphrase,
+		},
+		{
+			name:       "PBKDF2ControlCharacters",
+			keystore:   keystorePBKDF2,
+			passphrase: "test\npass\x7fword\U0001F511",
+		},
+		{
+			name:       "PBKDF2Unicode",
+			keystore:   keystorePBKDF2,
+			passphrase: keystoreUnicodePassphrase,
+		},
+		{
+			name:       "Scrypt",
+			keystore:   keystoreScrypt,
+			passphrase: keystorePassphrase,
+		},
+		{
+			name:       "ScryptUnicode",
+			keystore:   keystoreScrypt,
+			passphrase: keystoreUnicodePassphrase,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, err := util.PrivateKeyFromKeystore([]byte(test.keystore), test.passphrase)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, _byteArray(keystoreSecret), key.Marshal())
+			}
+		})
+	}
+}
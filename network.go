@@ -0,0 +1,369 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Fork is a fork of a network.
+type Fork struct {
+	Name    string
+	Version []byte
+	// Epoch is the epoch at which the fork activates.
+	Epoch uint64
+}
+
+// Network is the information about a network required to compute signing domains.
+type Network struct {
+	Name                  string
+	GenesisValidatorsRoot []byte
+	// Forks are the forks of the network in order of activation, starting with the genesis fork at epoch 0.
+	Forks []*Fork
+}
+
+// The registered networks, keyed by name.
+//
+//nolint:gochecknoglobals
+var (
+	networksMu sync.RWMutex
+	networks   = builtinNetworks()
+)
+
+// builtinNetworks returns the built-in network presets, keyed by name.
+func builtinNetworks() map[string]*Network {
+	res := make(map[string]*Network)
+	for _, network := range []*Network{
+		{
+			Name: "mainnet",
+			GenesisValidatorsRoot: []byte{
+				0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20,
+				0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e,
+				0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6,
+				0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95,
+			},
+			Forks: []*Fork{
+				{Name: "phase0", Version: []byte{0x00, 0x00, 0x00, 0x00}, Epoch: 0},
+				{Name: "altair", Version: []byte{0x01, 0x00, 0x00, 0x00}, Epoch: 74240},
+				{Name: "bellatrix", Version: []byte{0x02, 0x00, 0x00, 0x00}, Epoch: 144896},
+				{Name: "capella", Version: []byte{0x03, 0x00, 0x00, 0x00}, Epoch: 194048},
+				{Name: "deneb", Version: []byte{0x04, 0x00, 0x00, 0x00}, Epoch: 269568},
+				{Name: "electra", Version: []byte{0x05, 0x00, 0x00, 0x00}, Epoch: 364032},
+				{Name: "fulu", Version: []byte{0x06, 0x00, 0x00, 0x00}, Epoch: 411392},
+			},
+		},
+		{
+			Name: "sepolia",
+			GenesisValidatorsRoot: []byte{
+				0xd8, 0xea, 0x17, 0x1f, 0x3c, 0x94, 0xae, 0xa2,
+				0x1e, 0xbc, 0x42, 0xa1, 0xed, 0x61, 0x05, 0x2a,
+				0xcf, 0x3f, 0x92, 0x09, 0xc0, 0x0e, 0x4e, 0xfb,
+				0xaa, 0xdd, 0xac, 0x09, 0xed, 0x9b, 0x80, 0x78,
+			},
+			Forks: []*Fork{
+				{Name: "phase0", Version: []byte{0x90, 0x00, 0x00, 0x69}, Epoch: 0},
+				{Name: "altair", Version: []byte{0x90, 0x00, 0x00, 0x70}, Epoch: 50},
+				{Name: "bellatrix", Version: []byte{0x90, 0x00, 0x00, 0x71}, Epoch: 100},
+				{Name: "capella", Version: []byte{0x90, 0x00, 0x00, 0x72}, Epoch: 56832},
+				{Name: "deneb", Version: []byte{0x90, 0x00, 0x00, 0x73}, Epoch: 132608},
+				{Name: "electra", Version: []byte{0x90, 0x00, 0x00, 0x74}, Epoch: 222464},
+				{Name: "fulu", Version: []byte{0x90, 0x00, 0x00, 0x75}, Epoch: 272640},
+			},
+		},
+		{
+			Name: "holesky",
+			GenesisValidatorsRoot: []byte{
+				0x91, 0x43, 0xaa, 0x7c, 0x61, 0x5a, 0x7f, 0x71,
+				0x15, 0xe2, 0xb6, 0xaa, 0xc3, 0x19, 0xc0, 0x35,
+				0x29, 0xdf, 0x82, 0x42, 0xae, 0x70, 0x5f, 0xba,
+				0x9d, 0xf3, 0x9b, 0x79, 0xc5, 0x9f, 0xa8, 0xb1,
+			},
+			Forks: []*Fork{
+				{Name: "phase0", Version: []byte{0x01, 0x01, 0x70, 0x00}, Epoch: 0},
+				{Name: "altair", Version: []byte{0x02, 0x01, 0x70, 0x00}, Epoch: 0},
+				{Name: "bellatrix", Version: []byte{0x03, 0x01, 0x70, 0x00}, Epoch: 0},
+				{Name: "capella", Version: []byte{0x04, 0x01, 0x70, 0x00}, Epoch: 256},
+				{Name: "deneb", Version: []byte{0x05, 0x01, 0x70, 0x00}, Epoch: 29696},
+				{Name: "electra", Version: []byte{0x06, 0x01, 0x70, 0x00}, Epoch: 115968},
+				{Name: "fulu", Version: []byte{0x07, 0x01, 0x70, 0x00}, Epoch: 165120},
+			},
+		},
+		{
+			Name: "hoodi",
+			GenesisValidatorsRoot: []byte{
+				0x21, 0x2f, 0x13, 0xfc, 0x4d, 0xf0, 0x78, 0xb6,
+				0xcb, 0x7d, 0xb2, 0x28, 0xf1, 0xc8, 0x30, 0x75,
+				0x66, 0xdc, 0xec, 0xf9, 0x00, 0x86, 0x74, 0x01,
+				0xa9, 0x20, 0x23, 0xd7, 0xba, 0x99, 0xcb, 0x5f,
+			},
+			Forks: []*Fork{
+				{Name: "phase0", Version: []byte{0x10, 0x00, 0x09, 0x10}, Epoch: 0},
+				{Name: "altair", Version: []byte{0x20, 0x00, 0x09, 0x10}, Epoch: 0},
+				{Name: "bellatrix", Version: []byte{0x30, 0x00, 0x09, 0x10}, Epoch: 0},
+				{Name: "capella", Version: []byte{0x40, 0x00, 0x09, 0x10}, Epoch: 0},
+				{Name: "deneb", Version: []byte{0x50, 0x00, 0x09, 0x10}, Epoch: 0},
+				{Name: "electra", Version: []byte{0x60, 0x00, 0x09, 0x10}, Epoch: 2048},
+				{Name: "fulu", Version: []byte{0x70, 0x00, 0x09, 0x10}, Epoch: 50688},
+			},
+		},
+	} {
+		res[network.Name] = network
+	}
+
+	return res
+}
+
+// NetworkByName returns a copy of the registered network with the given name.
+// The presets mainnet, sepolia, holesky and hoodi are always registered.
+func NetworkByName(name string) (*Network, error) {
+	networksMu.RLock()
+	defer networksMu.RUnlock()
+
+	network, exists := networks[strings.ToLower(name)]
+	if !exists {
+		return nil, fmt.Errorf("unknown network %q", name)
+	}
+
+	return network.copy(), nil
+}
+
+// NetworkNames returns the names of the registered networks, in alphabetical order.
+func NetworkNames() []string {
+	networksMu.RLock()
+	defer networksMu.RUnlock()
+
+	res := make([]string, 0, len(networks))
+	for name := range networks {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+
+	return res
+}
+
+// RegisterNetwork registers a copy of a network, replacing any network with the same name that was previously
+// registered.  The presets cannot be replaced.
+func RegisterNetwork(network *Network) error {
+	if err := network.validate(); err != nil {
+		return err
+	}
+	name := strings.ToLower(network.Name)
+	if _, isPreset := builtinNetworks()[name]; isPreset {
+		return fmt.Errorf("network %s is a preset and cannot be replaced", name)
+	}
+
+	networksMu.Lock()
+	defer networksMu.Unlock()
+	networks[name] = network.copy()
+
+	return nil
+}
+
+// copy returns a deep copy of the network, so that the registered networks cannot be altered by callers.
+func (n *Network) copy() *Network {
+	res := &Network{
+		Name:                  n.Name,
+		GenesisValidatorsRoot: append([]byte(nil), n.GenesisValidatorsRoot...),
+		Forks:                 make([]*Fork, len(n.Forks)),
+	}
+	for i, fork := range n.Forks {
+		res.Forks[i] = &Fork{
+			Name:    fork.Name,
+			Version: append([]byte(nil), fork.Version...),
+			Epoch:   fork.Epoch,
+		}
+	}
+
+	return res
+}
+
+// validate checks that the network is usable.
+func (n *Network) validate() error {
+	if n == nil {
+		return errors.New("no network supplied")
+	}
+	if n.Name == "" {
+		return errors.New("network has no name")
+	}
+	if len(n.GenesisValidatorsRoot) != 32 {
+		return errors.New("genesis validators root must be 32 bytes")
+	}
+	if len(n.Forks) == 0 {
+		return errors.New("network has no forks")
+	}
+	for i, fork := range n.Forks {
+		if fork == nil {
+			return fmt.Errorf("fork %d not supplied", i)
+		}
+		if len(fork.Version) != 4 {
+			return fmt.Errorf("version of fork %s must be 4 bytes", fork.Name)
+		}
+		if i == 0 && fork.Epoch != 0 {
+			return fmt.Errorf("genesis fork %s must be at epoch 0", fork.Name)
+		}
+		if i > 0 && fork.Epoch < n.Forks[i-1].Epoch {
+			return fmt.Errorf("fork %s is before fork %s", fork.Name, n.Forks[i-1].Name)
+		}
+	}
+
+	return nil
+}
+
+// GenesisForkVersion returns the fork version of the network at genesis.
+func (n *Network) GenesisForkVersion() []byte {
+	return n.Forks[0].Version
+}
+
+// ForkAtEpoch returns the fork of the network that is active at the given epoch.
+func (n *Network) ForkAtEpoch(epoch uint64) *Fork {
+	res := n.Forks[0]
+	for _, fork := range n.Forks[1:] {
+		if fork.Epoch > epoch {
+			break
+		}
+		res = fork
+	}
+
+	return res
+}
+
+// SigningRoot computes the signing root of an object root with the domain of the given type at the given epoch.
+// The epoch is the current epoch of the chain when the object is signed, which for most objects is the epoch that
+// they name.
+// Deposits and builder API messages are signed with the genesis fork version and a zero genesis validators root,
+// so are valid at any epoch.  Voluntary exits signed once the chain has reached Deneb use the Capella fork version
+// whatever epoch they name (EIP-7044), so callers must pass the current epoch rather than the exit's epoch.
+func (n *Network) SigningRoot(objectRoot []byte, domainType [4]byte, epoch uint64) ([]byte, error) {
+	forkVersion, genesisValidatorsRoot, err := n.domainParameters(domainType, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeSigningRoot(objectRoot, domainType, forkVersion, genesisValidatorsRoot)
+}
+
+// domainParameters returns the fork version and genesis validators root of the domain of the given type when the
+// chain is at the given epoch.
+func (n *Network) domainParameters(domainType [4]byte, epoch uint64) ([]byte, []byte, error) {
+	switch domainType {
+	case domainDeposit, DomainApplicationBuilder:
+		return n.GenesisForkVersion(), make([]byte, 32), nil
+	case domainVoluntaryExit:
+		deneb := n.forkByName("deneb")
+		if deneb == nil || deneb.Epoch > epoch {
+			break
+		}
+		capella := n.forkByName("capella")
+		if capella == nil {
+			return nil, nil, fmt.Errorf("network %s has no capella fork for voluntary exits", n.Name)
+		}
+
+		return capella.Version, n.GenesisValidatorsRoot, nil
+	}
+
+	return n.ForkAtEpoch(epoch).Version, n.GenesisValidatorsRoot, nil
+}
+
+// forkByName returns the fork of the network with the given name, or nil if there is no such fork.
+func (n *Network) forkByName(name string) *Fork {
+	for _, fork := range n.Forks {
+		if fork.Name == name {
+			return fork
+		}
+	}
+
+	return nil
+}
+
+// NetworkFromConfig creates a network from a consensus specification config.yaml and the network's genesis
+// validators root, which is not part of the configuration.
+// The network is named by CONFIG_NAME, and has a fork for GENESIS_FORK_VERSION and for each pair of
+// <FORK>_FORK_VERSION and <FORK>_FORK_EPOCH, in the order in which they appear.
+func NetworkFromConfig(data []byte, genesisValidatorsRoot []byte) (*Network, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrap(err, "invalid config")
+	}
+	if len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("config is not a mapping")
+	}
+	values := make(map[string]string)
+	keys := make([]string, 0)
+	content := doc.Content[0].Content
+	for i := 0; i+1 < len(content); i += 2 {
+		if content[i+1].Kind != yaml.ScalarNode {
+			// Lists and mappings such as blob schedules are not required.
+			continue
+		}
+		values[content[i].Value] = content[i+1].Value
+		keys = append(keys, content[i].Value)
+	}
+
+	network := &Network{
+		Name:                  strings.ToLower(values["CONFIG_NAME"]),
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}
+	if network.Name == "" {
+		return nil, errors.New("no CONFIG_NAME in config")
+	}
+	if _, exists := values["GENESIS_FORK_VERSION"]; !exists {
+		return nil, errors.New("no GENESIS_FORK_VERSION in config")
+	}
+	for _, key := range keys {
+		name, isForkVersion := strings.CutSuffix(key, "_FORK_VERSION")
+		if !isForkVersion {
+			continue
+		}
+		version, err := hex.DecodeString(strings.TrimPrefix(values[key], "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s", key)
+		}
+		fork := &Fork{
+			Name:    strings.ToLower(name),
+			Version: version,
+		}
+		if name == "GENESIS" {
+			fork.Name = "phase0"
+			network.Forks = append([]*Fork{fork}, network.Forks...)
+
+			continue
+		}
+		epoch, exists := values[name+"_FORK_EPOCH"]
+		if !exists {
+			return nil, fmt.Errorf("no %s_FORK_EPOCH in config", name)
+		}
+		fork.Epoch, err = strconv.ParseUint(epoch, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s_FORK_EPOCH", name)
+		}
+		network.Forks = append(network.Forks, fork)
+	}
+	// Forks are listed in order, but an unscheduled fork at the far future epoch may precede scheduled forks.
+	sort.SliceStable(network.Forks, func(i int, j int) bool {
+		return network.Forks[i].Epoch < network.Forks[j].Epoch
+	})
+	if err := network.validate(); err != nil {
+		return nil, err
+	}
+
+	return network, nil
+}
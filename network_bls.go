@@ -0,0 +1,75 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// SignRootForNetwork signs an object root with the domain of the given type at the given epoch on the named network.
+// The domain follows the same rules as Network.SigningRoot, so deposits, builder API messages and voluntary exits are
+// signed with the fork versions that the chain expects for them.  As there, the epoch is the current epoch of the
+// chain, not the epoch named by a voluntary exit.
+func SignRootForNetwork(key *e2types.BLSPrivateKey,
+	networkName string,
+	epoch uint64,
+	objectRoot []byte,
+	domainType e2types.DomainType,
+) (
+	e2types.Signature,
+	error,
+) {
+	network, err := NetworkByName(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	forkVersion, genesisValidatorsRoot, err := network.domainParameters(domainType, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return signRoot(key, objectRoot, domainType, forkVersion, genesisValidatorsRoot)
+}
+
+// NewDepositDataForNetwork creates deposit data for a new validator with the given key on the named network.
+// The amount must be at least MinDepositAmount and no more than the maximum effective balance of a validator with
+// the given withdrawal credentials.
+func NewDepositDataForNetwork(key *e2types.BLSPrivateKey,
+	withdrawalCredentials []byte,
+	amount Gwei,
+	networkName string,
+) (
+	*DepositData,
+	error,
+) {
+	network, err := NetworkByName(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDepositData(key, withdrawalCredentials, amount, network.GenesisForkVersion())
+}
+
+// VerifyDepositDataJSONForNetwork verifies the deposits in a deposit data file for the named network.
+func VerifyDepositDataJSONForNetwork(data []byte, networkName string) ([]*DepositVerification, error) {
+	network, err := NetworkByName(networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	return VerifyDepositDataJSON(data, network.GenesisForkVersion())
+}
@@ -0,0 +1,101 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestSignRootForNetwork(t *testing.T) {
+	key, err := util.PrivateKeyFromSeedAndPath(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	objectRoot := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+
+	_, err = util.SignRootForNetwork(key, "unknown", 0, objectRoot, e2types.DomainRANDAO)
+	require.EqualError(t, err, `unknown network "unknown"`)
+	_, err = util.SignRootForNetwork(nil, "hoodi", 0, objectRoot, e2types.DomainRANDAO)
+	require.EqualError(t, err, "no key supplied")
+
+	signature, err := util.SignRootForNetwork(key, "hoodi", 3000, objectRoot, e2types.DomainRANDAO)
+	require.NoError(t, err)
+	hoodi, err := util.NetworkByName("hoodi")
+	require.NoError(t, err)
+	signingRoot, err := hoodi.SigningRoot(objectRoot, e2types.DomainRANDAO, 3000)
+	require.NoError(t, err)
+	assert.True(t, signature.Verify(signingRoot, key.PublicKey()))
+	// The signature is for the electra fork, so does not verify for an earlier epoch.
+	signingRoot, err = hoodi.SigningRoot(objectRoot, e2types.DomainRANDAO, 0)
+	require.NoError(t, err)
+	assert.False(t, signature.Verify(signingRoot, key.PublicKey()))
+
+	// Deposits are signed for the genesis fork, as with DepositSigningRoot.
+	message := &util.DepositMessage{
+		PublicKey:             key.PublicKey().Marshal(),
+		WithdrawalCredentials: make([]byte, 32),
+		Amount:                util.MinDepositAmount,
+	}
+	messageRoot, err := message.HashTreeRoot()
+	require.NoError(t, err)
+	signature, err = util.SignRootForNetwork(key, "hoodi", 3000, messageRoot, e2types.DomainDeposit)
+	require.NoError(t, err)
+	signingRoot, err = util.DepositSigningRoot(message, hoodi.GenesisForkVersion())
+	require.NoError(t, err)
+	assert.True(t, signature.Verify(signingRoot, key.PublicKey()))
+
+	// Voluntary exits after Deneb are signed for the Capella fork.
+	signature, err = util.SignRootForNetwork(key, "hoodi", 3000, objectRoot, e2types.DomainVoluntaryExit)
+	require.NoError(t, err)
+	domain, err := e2types.ComputeDomain(e2types.DomainVoluntaryExit, []byte{0x40, 0x00, 0x09, 0x10}, hoodi.GenesisValidatorsRoot)
+	require.NoError(t, err)
+	signingRoot, err = util.ComputeSigningRoot(objectRoot, domain)
+	require.NoError(t, err)
+	assert.True(t, signature.Verify(signingRoot, key.PublicKey()))
+}
+
+func TestNewDepositDataForNetwork(t *testing.T) {
+	key, err := util.PrivateKeyFromSeedAndPath(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"), "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	withdrawalCredentials, err := util.BLSWithdrawalCredentials(key.PublicKey().Marshal())
+	require.NoError(t, err)
+
+	_, err = util.NewDepositDataForNetwork(key, withdrawalCredentials, util.Gwei(util.MaxEffectiveBalance), "unknown")
+	require.EqualError(t, err, `unknown network "unknown"`)
+	_, err = util.VerifyDepositDataJSONForNetwork([]byte("[]"), "unknown")
+	require.EqualError(t, err, `unknown network "unknown"`)
+
+	deposit, err := util.NewDepositDataForNetwork(key, withdrawalCredentials, util.Gwei(util.MaxEffectiveBalance), "sepolia")
+	require.NoError(t, err)
+	data, err := json.Marshal([]map[string]any{depositRecord(t, deposit, _byteArray("90000069"))})
+	require.NoError(t, err)
+
+	res, err := util.VerifyDepositDataJSONForNetwork(data, "sepolia")
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.True(t, res[0].Valid())
+
+	res, err = util.VerifyDepositDataJSONForNetwork(data, "mainnet")
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, []string{
+		"fork version 0x90000069 does not match genesis fork version 0x00000000",
+	}, res[0].Problems)
+}
@@ -0,0 +1,495 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestNetworkByName(t *testing.T) {
+	_, err := util.NetworkByName("unknown")
+	require.EqualError(t, err, `unknown network "unknown"`)
+
+	tests := []struct {
+		name                  string
+		genesisForkVersion    []byte
+		genesisValidatorsRoot []byte
+	}{
+		{
+			name:                  "mainnet",
+			genesisForkVersion:    _byteArray("00000000"),
+			genesisValidatorsRoot: _byteArray("4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"),
+		},
+		{
+			name:                  "Sepolia",
+			genesisForkVersion:    _byteArray("90000069"),
+			genesisValidatorsRoot: _byteArray("d8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078"),
+		},
+		{
+			name:                  "holesky",
+			genesisForkVersion:    _byteArray("01017000"),
+			genesisValidatorsRoot: _byteArray("9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1"),
+		},
+		{
+			name:                  "HOODI",
+			genesisForkVersion:    _byteArray("10000910"),
+			genesisValidatorsRoot: _byteArray("212f13fc4df078b6cb7db228f1c8307566dcecf900867401a92023d7ba99cb5f"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			network, err := util.NetworkByName(test.name)
+			require.NoError(t, err)
+			assert.Equal(t, test.genesisForkVersion, network.GenesisForkVersion())
+			assert.Equal(t, test.genesisValidatorsRoot, network.GenesisValidatorsRoot)
+			assert.Contains(t, util.NetworkNames(), network.Name)
+		})
+	}
+}
+
+func TestForkAtEpoch(t *testing.T) {
+	mainnet, err := util.NetworkByName("mainnet")
+	require.NoError(t, err)
+	holesky, err := util.NetworkByName("holesky")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		network *util.Network
+		epoch   uint64
+		fork    string
+		version []byte
+	}{
+		{
+			name:    "Genesis",
+			network: mainnet,
+			epoch:   0,
+			fork:    "phase0",
+			version: _byteArray("00000000"),
+		},
+		{
+			name:    "BeforeFork",
+			network: mainnet,
+			epoch:   74239,
+			fork:    "phase0",
+			version: _byteArray("00000000"),
+		},
+		{
+			name:    "AtFork",
+			network: mainnet,
+			epoch:   74240,
+			fork:    "altair",
+			version: _byteArray("01000000"),
+		},
+		{
+			name:    "Electra",
+			network: mainnet,
+			epoch:   400000,
+			fork:    "electra",
+			version: _byteArray("05000000"),
+		},
+		{
+			name:    "FarFuture",
+			network: mainnet,
+			epoch:   math.MaxUint64,
+			fork:    "fulu",
+			version: _byteArray("06000000"),
+		},
+		{
+			name:    "ForksAtGenesis",
+			network: holesky,
+			epoch:   0,
+			fork:    "bellatrix",
+			version: _byteArray("03017000"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fork := test.network.ForkAtEpoch(test.epoch)
+			assert.Equal(t, test.fork, fork.Name)
+			assert.Equal(t, test.version, fork.Version)
+		})
+	}
+}
+
+func TestNetworkSigningRoot(t *testing.T) {
+	mainnet, err := util.NetworkByName("mainnet")
+	require.NoError(t, err)
+	objectRoot := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+
+	_, err = mainnet.SigningRoot(objectRoot[:31], [4]byte{0x00, 0x00, 0x00, 0x00}, 0)
+	require.EqualError(t, err, "object root must be 32 bytes")
+
+	signingRoot, err := mainnet.SigningRoot(objectRoot, [4]byte{0x00, 0x00, 0x00, 0x00}, 0)
+	require.NoError(t, err)
+	expected, err := util.ComputeSigningRoot(objectRoot, _byteArray("00000000b5303f2ad2010d699a76c8e62350947421a3e4a979779642cfdb0f66"))
+	require.NoError(t, err)
+	assert.Equal(t, expected, signingRoot)
+
+	// The domain changes with the fork.
+	electraRoot, err := mainnet.SigningRoot(objectRoot, [4]byte{0x00, 0x00, 0x00, 0x00}, 364032)
+	require.NoError(t, err)
+	assert.NotEqual(t, signingRoot, electraRoot)
+}
+
+func TestNetworkSigningRootDomains(t *testing.T) {
+	mainnet, err := util.NetworkByName("mainnet")
+	require.NoError(t, err)
+	noCapella := &util.Network{Name: "nocapella", GenesisValidatorsRoot: mainnet.GenesisValidatorsRoot, Forks: []*util.Fork{
+		{Name: "phase0", Version: []byte{0x01, 0x00, 0x00, 0x00}},
+		{Name: "deneb", Version: []byte{0x02, 0x00, 0x00, 0x00}, Epoch: 10},
+	}}
+	objectRoot := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+
+	// domain follows compute_domain in the consensus specification.
+	domain := func(domainType [4]byte, forkVersion []byte, genesisValidatorsRoot []byte) []byte {
+		forkDataRoot := util.SHA256(append(forkVersion, make([]byte, 28)...), genesisValidatorsRoot)
+
+		return append(domainType[:], forkDataRoot[:28]...)
+	}
+
+	tests := []struct {
+		name       string
+		network    *util.Network
+		domainType [4]byte
+		epoch      uint64
+		domain     []byte
+		err        string
+	}{
+		{
+			name:       "RANDAO",
+			network:    mainnet,
+			domainType: [4]byte{0x02, 0x00, 0x00, 0x00},
+			epoch:      364032,
+			domain:     domain([4]byte{0x02, 0x00, 0x00, 0x00}, []byte{0x05, 0x00, 0x00, 0x00}, mainnet.GenesisValidatorsRoot),
+		},
+		{
+			name:       "Deposit",
+			network:    mainnet,
+			domainType: [4]byte{0x03, 0x00, 0x00, 0x00},
+			epoch:      364032,
+			domain:     domain([4]byte{0x03, 0x00, 0x00, 0x00}, []byte{0x00, 0x00, 0x00, 0x00}, make([]byte, 32)),
+		},
+		{
+			name:       "ApplicationBuilder",
+			network:    mainnet,
+			domainType: util.DomainApplicationBuilder,
+			epoch:      364032,
+			domain:     domain(util.DomainApplicationBuilder, []byte{0x00, 0x00, 0x00, 0x00}, make([]byte, 32)),
+		},
+		{
+			name:       "VoluntaryExitBellatrix",
+			network:    mainnet,
+			domainType: [4]byte{0x04, 0x00, 0x00, 0x00},
+			epoch:      150000,
+			domain:     domain([4]byte{0x04, 0x00, 0x00, 0x00}, []byte{0x02, 0x00, 0x00, 0x00}, mainnet.GenesisValidatorsRoot),
+		},
+		{
+			name:       "VoluntaryExitDeneb",
+			network:    mainnet,
+			domainType: [4]byte{0x04, 0x00, 0x00, 0x00},
+			epoch:      269568,
+			domain:     domain([4]byte{0x04, 0x00, 0x00, 0x00}, []byte{0x03, 0x00, 0x00, 0x00}, mainnet.GenesisValidatorsRoot),
+		},
+		{
+			name:       "VoluntaryExitElectra",
+			network:    mainnet,
+			domainType: [4]byte{0x04, 0x00, 0x00, 0x00},
+			epoch:      364032,
+			domain:     domain([4]byte{0x04, 0x00, 0x00, 0x00}, []byte{0x03, 0x00, 0x00, 0x00}, mainnet.GenesisValidatorsRoot),
+		},
+		{
+			name:       "VoluntaryExitNoCapella",
+			network:    noCapella,
+			domainType: [4]byte{0x04, 0x00, 0x00, 0x00},
+			epoch:      10,
+			err:        "network nocapella has no capella fork for voluntary exits",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signingRoot, err := test.network.SigningRoot(objectRoot, test.domainType, test.epoch)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				expected, err := util.ComputeSigningRoot(objectRoot, test.domain)
+				require.NoError(t, err)
+				assert.Equal(t, expected, signingRoot)
+			}
+		})
+	}
+}
+
+func TestNetworkSigningRootPreDenebExit(t *testing.T) {
+	mainnet, err := util.NetworkByName("mainnet")
+	require.NoError(t, err)
+	domainVoluntaryExit := [4]byte{0x04, 0x00, 0x00, 0x00}
+
+	// An exit of validator 1 that names epoch 150000, before Deneb, is signed when the chain is at epoch 364032.
+	exitEpoch := make([]byte, 32)
+	binary.LittleEndian.PutUint64(exitEpoch, 150000)
+	validatorIndex := make([]byte, 32)
+	binary.LittleEndian.PutUint64(validatorIndex, 1)
+	exitRoot := util.SHA256(exitEpoch, validatorIndex)
+
+	signingRoot, err := mainnet.SigningRoot(exitRoot, domainVoluntaryExit, 364032)
+	require.NoError(t, err)
+	// The domain is that of the Capella fork, following compute_domain in the consensus specification.
+	forkDataRoot := util.SHA256([]byte{0x03, 0x00, 0x00, 0x00}, make([]byte, 28), mainnet.GenesisValidatorsRoot)
+	expected, err := util.ComputeSigningRoot(exitRoot, append(domainVoluntaryExit[:], forkDataRoot[:28]...))
+	require.NoError(t, err)
+	assert.Equal(t, expected, signingRoot)
+
+	// Passing the exit's own epoch gives the Bellatrix domain, which the chain no longer accepts.
+	bellatrixRoot, err := mainnet.SigningRoot(exitRoot, domainVoluntaryExit, 150000)
+	require.NoError(t, err)
+	assert.NotEqual(t, expected, bellatrixRoot)
+}
+
+func TestRegisterNetwork(t *testing.T) {
+	genesisValidatorsRoot := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+
+	tests := []struct {
+		name    string
+		network *util.Network
+		err     string
+	}{
+		{
+			name: "Nil",
+			err:  "no network supplied",
+		},
+		{
+			name:    "NameMissing",
+			network: &util.Network{},
+			err:     "network has no name",
+		},
+		{
+			name:    "GenesisValidatorsRootInvalid",
+			network: &util.Network{Name: "test", GenesisValidatorsRoot: []byte{0x01}},
+			err:     "genesis validators root must be 32 bytes",
+		},
+		{
+			name:    "ForksMissing",
+			network: &util.Network{Name: "test", GenesisValidatorsRoot: genesisValidatorsRoot},
+			err:     "network has no forks",
+		},
+		{
+			name: "ForkNil",
+			network: &util.Network{Name: "test", GenesisValidatorsRoot: genesisValidatorsRoot, Forks: []*util.Fork{
+				nil,
+			}},
+			err: "fork 0 not supplied",
+		},
+		{
+			name: "ForkVersionInvalid",
+			network: &util.Network{Name: "test", GenesisValidatorsRoot: genesisValidatorsRoot, Forks: []*util.Fork{
+				{Name: "phase0", Version: []byte{0x01}},
+			}},
+			err: "version of fork phase0 must be 4 bytes",
+		},
+		{
+			name: "GenesisForkLate",
+			network: &util.Network{Name: "test", GenesisValidatorsRoot: genesisValidatorsRoot, Forks: []*util.Fork{
+				{Name: "phase0", Version: []byte{0x01, 0x00, 0x00, 0x00}, Epoch: 1},
+			}},
+			err: "genesis fork phase0 must be at epoch 0",
+		},
+		{
+			name: "ForksOutOfOrder",
+			network: &util.Network{Name: "test", GenesisValidatorsRoot: genesisValidatorsRoot, Forks: []*util.Fork{
+				{Name: "phase0", Version: []byte{0x01, 0x00, 0x00, 0x00}},
+				{Name: "altair", Version: []byte{0x02, 0x00, 0x00, 0x00}, Epoch: 10},
+				{Name: "bellatrix", Version: []byte{0x03, 0x00, 0x00, 0x00}, Epoch: 5},
+			}},
+			err: "fork bellatrix is before fork altair",
+		},
+		{
+			name: "Preset",
+			network: &util.Network{Name: "Mainnet", GenesisValidatorsRoot: genesisValidatorsRoot, Forks: []*util.Fork{
+				{Name: "phase0", Version: []byte{0x01, 0x00, 0x00, 0x00}},
+			}},
+			err: "network mainnet is a preset and cannot be replaced",
+		},
+		{
+			name: "Good",
+			network: &util.Network{Name: "RegisterTest", GenesisValidatorsRoot: genesisValidatorsRoot, Forks: []*util.Fork{
+				{Name: "phase0", Version: []byte{0x01, 0x00, 0x00, 0x00}},
+				{Name: "altair", Version: []byte{0x02, 0x00, 0x00, 0x00}, Epoch: 10},
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := util.RegisterNetwork(test.network)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				network, err := util.NetworkByName(test.network.Name)
+				require.NoError(t, err)
+				assert.Equal(t, test.network, network)
+				assert.Contains(t, util.NetworkNames(), "registertest")
+			}
+		})
+	}
+}
+
+func TestNetworkCopies(t *testing.T) {
+	network := &util.Network{
+		Name:                  "copytest",
+		GenesisValidatorsRoot: _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"),
+		Forks: []*util.Fork{
+			{Name: "phase0", Version: []byte{0x01, 0x00, 0x00, 0x00}},
+		},
+	}
+	require.NoError(t, util.RegisterNetwork(network))
+
+	// Changing the network after registration does not change the registered network.
+	network.Forks[0].Version[0] = 0xff
+	network.GenesisValidatorsRoot[0] = 0xff
+	registered, err := util.NetworkByName("copytest")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x00, 0x00, 0x00}, registered.GenesisForkVersion())
+	assert.Equal(t, byte(0x01), registered.GenesisValidatorsRoot[0])
+
+	// Changing a returned network does not change the registered network.
+	mainnet, err := util.NetworkByName("mainnet")
+	require.NoError(t, err)
+	mainnet.Forks[0].Version[0] = 0xff
+	mainnet.Forks = mainnet.Forks[:1]
+	mainnet.GenesisValidatorsRoot[0] = 0xff
+	mainnet, err = util.NetworkByName("mainnet")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x00}, mainnet.GenesisForkVersion())
+	assert.Len(t, mainnet.Forks, 7)
+	assert.Equal(t, byte(0x4b), mainnet.GenesisValidatorsRoot[0])
+}
+
+func TestNetworkFromConfig(t *testing.T) {
+	genesisValidatorsRoot := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+	config := `# Extends the mainnet preset
+PRESET_BASE: 'mainnet'
+CONFIG_NAME: 'devnet'
+
+GENESIS_FORK_VERSION: 0x10000038
+ALTAIR_FORK_VERSION: 0x20000038
+ALTAIR_FORK_EPOCH: 0
+BELLATRIX_FORK_VERSION: 0x30000038
+BELLATRIX_FORK_EPOCH: 0
+GLOAS_FORK_VERSION: 0x80000038
+GLOAS_FORK_EPOCH: 18446744073709551615
+CAPELLA_FORK_VERSION: 0x40000038
+CAPELLA_FORK_EPOCH: 100
+
+SECONDS_PER_SLOT: 12
+BLOB_SCHEDULE:
+  - EPOCH: 100
+    MAX_BLOBS_PER_BLOCK: 12
+`
+
+	tests := []struct {
+		name   string
+		config string
+		gvr    []byte
+		err    string
+	}{
+		{
+			name:   "Invalid",
+			config: "a: [",
+			gvr:    genesisValidatorsRoot,
+			err:    "invalid config: yaml: line 1: did not find expected node content",
+		},
+		{
+			name:   "NotMapping",
+			config: "- a",
+			gvr:    genesisValidatorsRoot,
+			err:    "config is not a mapping",
+		},
+		{
+			name:   "NameMissing",
+			config: "GENESIS_FORK_VERSION: 0x10000038",
+			gvr:    genesisValidatorsRoot,
+			err:    "no CONFIG_NAME in config",
+		},
+		{
+			name:   "GenesisForkVersionMissing",
+			config: "CONFIG_NAME: devnet",
+			gvr:    genesisValidatorsRoot,
+			err:    "no GENESIS_FORK_VERSION in config",
+		},
+		{
+			name:   "ForkVersionInvalid",
+			config: "CONFIG_NAME: devnet\nGENESIS_FORK_VERSION: 0x1000003x",
+			gvr:    genesisValidatorsRoot,
+			err:    "invalid GENESIS_FORK_VERSION",
+		},
+		{
+			name:   "ForkEpochMissing",
+			config: "CONFIG_NAME: devnet\nGENESIS_FORK_VERSION: 0x10000038\nALTAIR_FORK_VERSION: 0x20000038",
+			gvr:    genesisValidatorsRoot,
+			err:    "no ALTAIR_FORK_EPOCH in config",
+		},
+		{
+			name:   "ForkEpochInvalid",
+			config: "CONFIG_NAME: devnet\nGENESIS_FORK_VERSION: 0x10000038\nALTAIR_FORK_VERSION: 0x20000038\nALTAIR_FORK_EPOCH: -1",
+			gvr:    genesisValidatorsRoot,
+			err:    "invalid ALTAIR_FORK_EPOCH",
+		},
+		{
+			name:   "GenesisValidatorsRootMissing",
+			config: config,
+			err:    "genesis validators root must be 32 bytes",
+		},
+		{
+			name:   "Good",
+			config: config,
+			gvr:    genesisValidatorsRoot,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			network, err := util.NetworkFromConfig([]byte(test.config), test.gvr)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &util.Network{
+				Name:                  "devnet",
+				GenesisValidatorsRoot: genesisValidatorsRoot,
+				Forks: []*util.Fork{
+					{Name: "phase0", Version: _byteArray("10000038"), Epoch: 0},
+					{Name: "altair", Version: _byteArray("20000038"), Epoch: 0},
+					{Name: "bellatrix", Version: _byteArray("30000038"), Epoch: 0},
+					{Name: "capella", Version: _byteArray("40000038"), Epoch: 100},
+					{Name: "gloas", Version: _byteArray("80000038"), Epoch: math.MaxUint64},
+				},
+			}, network)
+			assert.Equal(t, "capella", network.ForkAtEpoch(1000).Name)
+		})
+	}
+}
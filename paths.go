@@ -0,0 +1,195 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MaxPathTemplatePaths is the maximum number of paths that ExpandPathTemplate returns.  Larger templates can be
+// iterated over with a PathIterator.
+const MaxPathTemplatePaths = 1 << 20
+
+// SigningPath returns the ERC-2334 signing key path for an account.
+func SigningPath(account uint32) string {
+	return fmt.Sprintf("m/12381/3600/%d/0/0", account)
+}
+
+// WithdrawalPath returns the ERC-2334 withdrawal key path for an account.
+func WithdrawalPath(account uint32) string {
+	return fmt.Sprintf("m/12381/3600/%d/0", account)
+}
+
+// pathRange is an inclusive range of indices in a path template component.
+type pathRange struct {
+	first uint32
+	last  uint32
+}
+
+func (r pathRange) len() uint64 {
+	return uint64(r.last) - uint64(r.first) + 1
+}
+
+// PathIterator iterates over the paths of a path template.
+type PathIterator struct {
+	// components holds the ranges of each component after the master.
+	components [][]pathRange
+	sizes      []uint64
+	total      uint64
+	next       uint64
+}
+
+// NewPathIterator creates an iterator over the paths of a path template.
+// A template is a path in which any component after the master can be a set of indices in braces, made up of
+// comma-separated indices and inclusive ranges, for example "m/12381/3600/{0..999}/0/0" or
+// "m/12381/3600/{5,9,11}/0".  Paths are iterated in the order in which their indices are written, with later
+// components varying fastest.  A path without braces is a template for itself.
+func NewPathIterator(template string) (*PathIterator, error) {
+	if template == "" {
+		return nil, errors.New("no path")
+	}
+	pathBits := strings.Split(template, "/")
+	if pathBits[0] != "m" {
+		return nil, fmt.Errorf("not master at path component %d", 0)
+	}
+
+	iterator := &PathIterator{
+		components: make([][]pathRange, 0, len(pathBits)-1),
+		sizes:      make([]uint64, 0, len(pathBits)-1),
+		total:      1,
+	}
+	for i := 1; i < len(pathBits); i++ {
+		ranges, err := parsePathComponent(pathBits[i], i)
+		if err != nil {
+			return nil, err
+		}
+		size := uint64(0)
+		for _, r := range ranges {
+			size += r.len()
+		}
+		if iterator.total > math.MaxUint64/size {
+			return nil, errors.New("path template expands to too many paths")
+		}
+		iterator.total *= size
+		iterator.components = append(iterator.components, ranges)
+		iterator.sizes = append(iterator.sizes, size)
+	}
+
+	return iterator, nil
+}
+
+// parsePathComponent parses a component of a path template.
+func parsePathComponent(component string, pos int) ([]pathRange, error) {
+	switch {
+	case component == "":
+		return nil, fmt.Errorf("no entry at path component %d", pos)
+	case component == "m":
+		return nil, fmt.Errorf("invalid master at path component %d", pos)
+	case !strings.HasPrefix(component, "{"):
+		index, err := strconv.ParseUint(component, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %q at path component %d", component, pos)
+		}
+
+		return []pathRange{{first: uint32(index), last: uint32(index)}}, nil
+	}
+
+	if !strings.HasSuffix(component, "}") {
+		return nil, fmt.Errorf("unterminated set %q at path component %d", component, pos)
+	}
+	entries := strings.Split(component[1:len(component)-1], ",")
+	ranges := make([]pathRange, 0, len(entries))
+	for _, entry := range entries {
+		firstStr, lastStr, isRange := strings.Cut(strings.TrimSpace(entry), "..")
+		first, err := strconv.ParseUint(firstStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %q at path component %d", entry, pos)
+		}
+		last := first
+		if isRange {
+			last, err = strconv.ParseUint(lastStr, 10, 32)
+			if err != nil || last < first {
+				return nil, fmt.Errorf("invalid range %q at path component %d", entry, pos)
+			}
+		}
+		ranges = append(ranges, pathRange{first: uint32(first), last: uint32(last)})
+	}
+
+	return ranges, nil
+}
+
+// Len returns the total number of paths of the template.
+func (i *PathIterator) Len() uint64 {
+	return i.total
+}
+
+// Next returns the next path, or false if there are no more paths.
+func (i *PathIterator) Next() (string, bool) {
+	if i.next >= i.total {
+		return "", false
+	}
+
+	offsets := make([]uint64, len(i.components))
+	n := i.next
+	for j := len(i.components) - 1; j >= 0; j-- {
+		offsets[j] = n % i.sizes[j]
+		n /= i.sizes[j]
+	}
+	i.next++
+
+	var builder strings.Builder
+	builder.WriteString("m")
+	for j, offset := range offsets {
+		for _, r := range i.components[j] {
+			if offset < r.len() {
+				builder.WriteString("/")
+				builder.WriteString(strconv.FormatUint(uint64(r.first)+offset, 10))
+
+				break
+			}
+			offset -= r.len()
+		}
+	}
+
+	return builder.String(), true
+}
+
+// Reset returns the iterator to the first path.
+func (i *PathIterator) Reset() {
+	i.next = 0
+}
+
+// ExpandPathTemplate returns all of the paths of a path template.
+// Templates that expand to more than MaxPathTemplatePaths paths are rejected.
+func ExpandPathTemplate(template string) ([]string, error) {
+	iterator, err := NewPathIterator(template)
+	if err != nil {
+		return nil, err
+	}
+	if iterator.Len() > MaxPathTemplatePaths {
+		return nil, fmt.Errorf("path template expands to more than %d paths", MaxPathTemplatePaths)
+	}
+
+	res := make([]string, 0, iterator.Len())
+	for path, ok := iterator.Next(); ok; path, ok = iterator.Next() {
+		res = append(res, path)
+	}
+
+	return res, nil
+}
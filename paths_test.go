@@ -0,0 +1,174 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestSigningAndWithdrawalPaths(t *testing.T) {
+	assert.Equal(t, "m/12381/3600/5/0/0", util.SigningPath(5))
+	assert.Equal(t, "m/12381/3600/5/0", util.WithdrawalPath(5))
+}
+
+func TestExpandPathTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		err      string
+		paths    []string
+	}{
+		{
+			name: "Empty",
+			err:  "no path",
+		},
+		{
+			name:     "NotMaster",
+			template: "12381/3600",
+			err:      "not master at path component 0",
+		},
+		{
+			name:     "InvalidMaster",
+			template: "m/m",
+			err:      "invalid master at path component 1",
+		},
+		{
+			name:     "EmptyComponent",
+			template: "m/12381//0",
+			err:      "no entry at path component 2",
+		},
+		{
+			name:     "InvalidIndex",
+			template: "m/12381/a",
+			err:      `invalid index "a" at path component 2`,
+		},
+		{
+			name:     "Unterminated",
+			template: "m/12381/{0..9",
+			err:      `unterminated set "{0..9" at path component 2`,
+		},
+		{
+			name:     "EmptySet",
+			template: "m/12381/{}",
+			err:      `invalid index "" at path component 2`,
+		},
+		{
+			name:     "InvalidSetIndex",
+			template: "m/12381/{1,a}",
+			err:      `invalid index "a" at path component 2`,
+		},
+		{
+			name:     "RangeReversed",
+			template: "m/12381/{9..0}",
+			err:      `invalid range "9..0" at path component 2`,
+		},
+		{
+			name:     "RangeInvalid",
+			template: "m/12381/{0..a}",
+			err:      `invalid range "0..a" at path component 2`,
+		},
+		{
+			name:     "TooMany",
+			template: "m/{0..4294967295}/{0..4294967295}/{0..4294967295}",
+			err:      "path template expands to too many paths",
+		},
+		{
+			name:     "Master",
+			template: "m",
+			paths:    []string{"m"},
+		},
+		{
+			name:     "Path",
+			template: "m/12381/3600/0/0/0",
+			paths:    []string{"m/12381/3600/0/0/0"},
+		},
+		{
+			name:     "Range",
+			template: "m/12381/3600/{0..2}/0/0",
+			paths:    []string{"m/12381/3600/0/0/0", "m/12381/3600/1/0/0", "m/12381/3600/2/0/0"},
+		},
+		{
+			name:     "List",
+			template: "m/12381/3600/{5,9,11}/0",
+			paths:    []string{"m/12381/3600/5/0", "m/12381/3600/9/0", "m/12381/3600/11/0"},
+		},
+		{
+			name:     "Mixed",
+			template: "m/12381/3600/{7, 1..2}/{0,1}",
+			paths: []string{
+				"m/12381/3600/7/0",
+				"m/12381/3600/7/1",
+				"m/12381/3600/1/0",
+				"m/12381/3600/1/1",
+				"m/12381/3600/2/0",
+				"m/12381/3600/2/1",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paths, err := util.ExpandPathTemplate(test.template)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.paths, paths)
+			}
+		})
+	}
+}
+
+func TestPathIterator(t *testing.T) {
+	iterator, err := util.NewPathIterator("m/12381/3600/{0..4294967295}/0/0")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4294967296), iterator.Len())
+
+	path, ok := iterator.Next()
+	require.True(t, ok)
+	assert.Equal(t, "m/12381/3600/0/0/0", path)
+	path, ok = iterator.Next()
+	require.True(t, ok)
+	assert.Equal(t, "m/12381/3600/1/0/0", path)
+
+	iterator.Reset()
+	path, ok = iterator.Next()
+	require.True(t, ok)
+	assert.Equal(t, "m/12381/3600/0/0/0", path)
+
+	iterator, err = util.NewPathIterator("m/12381")
+	require.NoError(t, err)
+	_, ok = iterator.Next()
+	require.True(t, ok)
+	_, ok = iterator.Next()
+	require.False(t, ok)
+}
+
+func TestExpandPathTemplateTooLarge(t *testing.T) {
+	_, err := util.ExpandPathTemplate("m/12381/3600/{0..4294967295}/0/0")
+	require.EqualError(t, err, "path template expands to more than 1048576 paths")
+	_, err = util.ExpandPathTemplate("m/12381/3600/{0..2147483646}/0/0")
+	require.EqualError(t, err, "path template expands to more than 1048576 paths")
+	_, err = util.ExpandPathTemplate("m/12381/3600/{0..1023}/{0..1024}")
+	require.EqualError(t, err, "path template expands to more than 1048576 paths")
+
+	// Templates too large to expand can still be iterated over.
+	iterator, err := util.NewPathIterator("m/12381/3600/{0..2147483646}/0/0")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2147483647), iterator.Len())
+}
@@ -0,0 +1,171 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/pkg/errors"
+)
+
+// SlashingProtection checks that signing a block or attestation cannot result in slashing, and records it if so.
+// It is implemented by the store in the slashingprotection package.
+type SlashingProtection interface {
+	// CheckAndRecordBlock checks and records a block with the given signing root.
+	CheckAndRecordBlock(pubKey []byte, slot uint64, signingRoot []byte) error
+	// CheckAndRecordAttestation checks and records an attestation with the given signing root.
+	CheckAndRecordAttestation(pubKey []byte, sourceEpoch uint64, targetEpoch uint64, signingRoot []byte) error
+}
+
+// BeaconBlockHeader is a beacon block header.
+// The hash tree root of a header is the same as that of the block it summarises.
+type BeaconBlockHeader struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    []byte
+	StateRoot     []byte
+	BodyRoot      []byte
+}
+
+// HashTreeRoot returns the hash tree root of the header.
+func (h *BeaconBlockHeader) HashTreeRoot() ([]byte, error) {
+	return Merkleize([][]byte{
+		uint64Root(h.Slot),
+		uint64Root(h.ProposerIndex),
+		h.ParentRoot,
+		h.StateRoot,
+		h.BodyRoot,
+	})
+}
+
+// Checkpoint is a checkpoint.
+type Checkpoint struct {
+	Epoch uint64
+	Root  []byte
+}
+
+// HashTreeRoot returns the hash tree root of the checkpoint.
+func (c *Checkpoint) HashTreeRoot() ([]byte, error) {
+	return Merkleize([][]byte{
+		uint64Root(c.Epoch),
+		c.Root,
+	})
+}
+
+// AttestationData is the data of an attestation.
+type AttestationData struct {
+	Slot            uint64
+	Index           uint64
+	BeaconBlockRoot []byte
+	Source          *Checkpoint
+	Target          *Checkpoint
+}
+
+// HashTreeRoot returns the hash tree root of the attestation data.
+func (a *AttestationData) HashTreeRoot() ([]byte, error) {
+	if a.Source == nil {
+		return nil, errors.New("source missing")
+	}
+	if a.Target == nil {
+		return nil, errors.New("target missing")
+	}
+	sourceRoot, err := a.Source.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid source")
+	}
+	targetRoot, err := a.Target.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid target")
+	}
+
+	return Merkleize([][]byte{
+		uint64Root(a.Slot),
+		uint64Root(a.Index),
+		a.BeaconBlockRoot,
+		sourceRoot,
+		targetRoot,
+	})
+}
+
+// SignBeaconBlockHeaderWithBackend signs a beacon block header with a key from any BLS backend, after checking with
+// the slashing protection that doing so cannot result in slashing.
+func SignBeaconBlockHeaderWithBackend(key BLSPrivateKey,
+	protection SlashingProtection,
+	header *BeaconBlockHeader,
+	forkVersion []byte,
+	genesisValidatorsRoot []byte,
+) (
+	BLSSignature,
+	error,
+) {
+	if key == nil {
+		return nil, errors.New("no key supplied")
+	}
+	if protection == nil {
+		return nil, errors.New("no slashing protection supplied")
+	}
+	if header == nil {
+		return nil, errors.New("no header supplied")
+	}
+	root, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain header root")
+	}
+	signingRoot, err := computeSigningRoot(root, domainBeaconProposer, forkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+	if err := protection.CheckAndRecordBlock(key.PublicKey().Marshal(), header.Slot, signingRoot); err != nil {
+		return nil, errors.Wrap(err, "slashing protection refused block")
+	}
+
+	return key.Sign(signingRoot), nil
+}
+
+// SignAttestationDataWithBackend signs attestation data with a key from any BLS backend, after checking with the
+// slashing protection that doing so cannot result in slashing.
+func SignAttestationDataWithBackend(key BLSPrivateKey,
+	protection SlashingProtection,
+	data *AttestationData,
+	forkVersion []byte,
+	genesisValidatorsRoot []byte,
+) (
+	BLSSignature,
+	error,
+) {
+	if key == nil {
+		return nil, errors.New("no key supplied")
+	}
+	if protection == nil {
+		return nil, errors.New("no slashing protection supplied")
+	}
+	if data == nil {
+		return nil, errors.New("no attestation data supplied")
+	}
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain attestation data root")
+	}
+	signingRoot, err := computeSigningRoot(root, domainBeaconAttester, forkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+	if err := protection.CheckAndRecordAttestation(key.PublicKey().Marshal(),
+		data.Source.Epoch,
+		data.Target.Epoch,
+		signingRoot,
+	); err != nil {
+		return nil, errors.Wrap(err, "slashing protection refused attestation")
+	}
+
+	return key.Sign(signingRoot), nil
+}
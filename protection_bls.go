@@ -0,0 +1,65 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// SignBeaconBlockHeader signs a beacon block header, after checking with the slashing protection that
+// doing so cannot result in slashing.
+func SignBeaconBlockHeader(key *e2types.BLSPrivateKey,
+	protection SlashingProtection,
+	header *BeaconBlockHeader,
+	forkVersion []byte,
+	genesisValidatorsRoot []byte,
+) (
+	e2types.Signature,
+	error,
+) {
+	if key == nil {
+		return nil, errors.New("no key supplied")
+	}
+	sig, err := SignBeaconBlockHeaderWithBackend(HerumiPrivateKey(key), protection, header, forkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return sig.(*herumiSignature).sig, nil
+}
+
+// SignAttestationData signs attestation data, after checking with the slashing protection that
+// doing so cannot result in slashing.
+func SignAttestationData(key *e2types.BLSPrivateKey,
+	protection SlashingProtection,
+	data *AttestationData,
+	forkVersion []byte,
+	genesisValidatorsRoot []byte,
+) (
+	e2types.Signature,
+	error,
+) {
+	if key == nil {
+		return nil, errors.New("no key supplied")
+	}
+	sig, err := SignAttestationDataWithBackend(HerumiPrivateKey(key), protection, data, forkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return sig.(*herumiSignature).sig, nil
+}
@@ -0,0 +1,170 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+	"github.com/wealdtech/go-eth2-util/slashingprotection"
+)
+
+func TestBeaconBlockHeaderHashTreeRoot(t *testing.T) {
+	header := &util.BeaconBlockHeader{
+		Slot:          1,
+		ProposerIndex: 2,
+		ParentRoot:    bytes.Repeat([]byte{0x01}, 32),
+		StateRoot:     bytes.Repeat([]byte{0x02}, 32),
+		BodyRoot:      bytes.Repeat([]byte{0x03}, 32),
+	}
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, _byteArray("bb3917003756216bccee4f87d53d4e52b948b8190b14a552cb1e78fcede06dd5"), root)
+
+	header.BodyRoot = nil
+	_, err = header.HashTreeRoot()
+	require.EqualError(t, err, "chunk 4 is 0 bytes, must be 32")
+}
+
+func TestAttestationDataHashTreeRoot(t *testing.T) {
+	data := &util.AttestationData{
+		Slot:            64,
+		Index:           3,
+		BeaconBlockRoot: bytes.Repeat([]byte{0x06}, 32),
+		Source:          &util.Checkpoint{Epoch: 1, Root: bytes.Repeat([]byte{0x04}, 32)},
+		Target:          &util.Checkpoint{Epoch: 2, Root: bytes.Repeat([]byte{0x05}, 32)},
+	}
+	root, err := data.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, _byteArray("47bac91d4c87e2d9e72710e507bbd49622b0033190da41b0f627334b18f63f0a"), root)
+
+	data.Target = nil
+	_, err = data.HashTreeRoot()
+	require.EqualError(t, err, "target missing")
+}
+
+func TestSignWithSlashingProtection(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	key, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	forkVersion := []byte{0x00, 0x00, 0x00, 0x00}
+	genesisValidatorsRoot := bytes.Repeat([]byte{0x01}, 32)
+
+	store, err := slashingprotection.NewStore(filepath.Join(t.TempDir(), "protection.json"), genesisValidatorsRoot)
+	require.NoError(t, err)
+
+	_, err = util.SignBeaconBlockHeader(key, nil, &util.BeaconBlockHeader{}, forkVersion, genesisValidatorsRoot)
+	require.EqualError(t, err, "no slashing protection supplied")
+
+	header := &util.BeaconBlockHeader{
+		Slot:       10,
+		ParentRoot: bytes.Repeat([]byte{0x01}, 32),
+		StateRoot:  bytes.Repeat([]byte{0x02}, 32),
+		BodyRoot:   bytes.Repeat([]byte{0x03}, 32),
+	}
+	sig, err := util.SignBeaconBlockHeader(key, store, header, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	domain, err := e2types.ComputeDomain(e2types.DomainBeaconProposer, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	signingRoot, err := util.ComputeSigningRoot(root, domain)
+	require.NoError(t, err)
+	assert.True(t, sig.Verify(signingRoot, key.PublicKey()))
+	resigned, err := util.SignBeaconBlockHeader(key, store, header, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	assert.Equal(t, sig.Marshal(), resigned.Marshal())
+
+	header.StateRoot = bytes.Repeat([]byte{0x04}, 32)
+	_, err = util.SignBeaconBlockHeader(key, store, header, forkVersion, genesisValidatorsRoot)
+	require.EqualError(t, err, "slashing protection refused block: slashable: double proposal at slot 10")
+	assert.True(t, errors.Is(err, slashingprotection.ErrSlashable))
+
+	data := &util.AttestationData{
+		Slot:            320,
+		BeaconBlockRoot: bytes.Repeat([]byte{0x06}, 32),
+		Source:          &util.Checkpoint{Epoch: 8, Root: bytes.Repeat([]byte{0x04}, 32)},
+		Target:          &util.Checkpoint{Epoch: 10, Root: bytes.Repeat([]byte{0x05}, 32)},
+	}
+	_, err = util.SignAttestationData(key, store, data, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+
+	data.Source.Epoch = 9
+	data.Target.Epoch = 9
+	_, err = util.SignAttestationData(key, store, data, forkVersion, genesisValidatorsRoot)
+	require.EqualError(t, err, "slashing protection refused attestation: slashable: 9->9 is surrounded by 8->10")
+
+	interchange := store.Export(key.PublicKey().Marshal())
+	require.Len(t, interchange.Records, 1)
+	assert.Len(t, interchange.Records[0].SignedBlocks, 1)
+	assert.Len(t, interchange.Records[0].SignedAttestations, 1)
+}
+
+// TestSignWithSlashingProtectionWithBackend checks that signing with a key from another backend matches signing with
+// the default backend, and is subject to the same slashing protection.
+func TestSignWithSlashingProtectionWithBackend(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	key, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	referenceKey, err := util.PrivateKeyFromSeedAndPathWithBackend(util.ReferenceBLSBackend(), seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	forkVersion := []byte{0x00, 0x00, 0x00, 0x00}
+	genesisValidatorsRoot := bytes.Repeat([]byte{0x01}, 32)
+
+	store, err := slashingprotection.NewStore(filepath.Join(t.TempDir(), "protection.json"), genesisValidatorsRoot)
+	require.NoError(t, err)
+	referenceStore, err := slashingprotection.NewStore(filepath.Join(t.TempDir(), "protection.json"), genesisValidatorsRoot)
+	require.NoError(t, err)
+
+	_, err = util.SignBeaconBlockHeaderWithBackend(nil, referenceStore, &util.BeaconBlockHeader{}, forkVersion, genesisValidatorsRoot)
+	require.EqualError(t, err, "no key supplied")
+	_, err = util.SignAttestationDataWithBackend(referenceKey, nil, &util.AttestationData{}, forkVersion, genesisValidatorsRoot)
+	require.EqualError(t, err, "no slashing protection supplied")
+
+	header := &util.BeaconBlockHeader{
+		Slot:       10,
+		ParentRoot: bytes.Repeat([]byte{0x01}, 32),
+		StateRoot:  bytes.Repeat([]byte{0x02}, 32),
+		BodyRoot:   bytes.Repeat([]byte{0x03}, 32),
+	}
+	expected, err := util.SignBeaconBlockHeader(key, store, header, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	sig, err := util.SignBeaconBlockHeaderWithBackend(referenceKey, referenceStore, header, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Marshal(), sig.Marshal())
+
+	data := &util.AttestationData{
+		Slot:            320,
+		BeaconBlockRoot: bytes.Repeat([]byte{0x06}, 32),
+		Source:          &util.Checkpoint{Epoch: 8, Root: bytes.Repeat([]byte{0x04}, 32)},
+		Target:          &util.Checkpoint{Epoch: 10, Root: bytes.Repeat([]byte{0x05}, 32)},
+	}
+	expected, err = util.SignAttestationData(key, store, data, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	sig, err = util.SignAttestationDataWithBackend(referenceKey, referenceStore, data, forkVersion, genesisValidatorsRoot)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Marshal(), sig.Marshal())
+
+	header.StateRoot = bytes.Repeat([]byte{0x04}, 32)
+	_, err = util.SignBeaconBlockHeaderWithBackend(referenceKey, referenceStore, header, forkVersion, genesisValidatorsRoot)
+	require.EqualError(t, err, "slashing protection refused block: slashable: double proposal at slot 10")
+}
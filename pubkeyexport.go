@@ -0,0 +1,229 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// PublicKeyFormat is the format in which public keys are exported.
+type PublicKeyFormat int
+
+const (
+	// PublicKeyFormatJSON exports public keys as a JSON array of objects.
+	PublicKeyFormatJSON PublicKeyFormat = iota
+	// PublicKeyFormatCSV exports public keys as CSV with a header line.
+	PublicKeyFormatCSV
+	// PublicKeyFormatSSZ exports public keys as an SSZ list of containers, each holding the account index as a
+	// uint32 followed by the signing public key, the withdrawal public key and the withdrawal credentials.
+	// The paths of the keys are the ERC-2334 signing and withdrawal paths of the account.
+	PublicKeyFormatSSZ
+)
+
+// AccountPublicKeys are the public keys of an ERC-2334 account.
+type AccountPublicKeys struct {
+	Account               uint32
+	SigningPath           string
+	SigningPublicKey      []byte
+	WithdrawalPath        string
+	WithdrawalPublicKey   []byte
+	WithdrawalCredentials []byte
+}
+
+// accountPublicKeysJSON is the JSON representation of account public keys.
+type accountPublicKeysJSON struct {
+	Account               string `json:"account"`
+	SigningPath           string `json:"signing_path"`
+	SigningPublicKey      string `json:"signing_pubkey"`
+	WithdrawalPath        string `json:"withdrawal_path"`
+	WithdrawalPublicKey   string `json:"withdrawal_pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+}
+
+// AccountPublicKeys derives the signing and withdrawal public keys of an account.
+func (d *KeyDeriver) AccountPublicKeys(account uint32) (*AccountPublicKeys, error) {
+	res := &AccountPublicKeys{
+		Account:        account,
+		SigningPath:    SigningPath(account),
+		WithdrawalPath: WithdrawalPath(account),
+	}
+	// The withdrawal key is the parent of the signing key, so is cached when the signing key is derived.
+	signingKey, err := d.PublicKey(res.SigningPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to derive signing key for account %d", account)
+	}
+	withdrawalKey, err := d.PublicKey(res.WithdrawalPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to derive withdrawal key for account %d", account)
+	}
+	res.SigningPublicKey = signingKey.Marshal()
+	res.WithdrawalPublicKey = withdrawalKey.Marshal()
+	res.WithdrawalCredentials, err = BLSWithdrawalCredentials(res.WithdrawalPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ExportPublicKeys derives the signing and withdrawal public keys of accounts first to last inclusive, and writes
+// them in the given format.
+// Keys are written as they are derived and the deriver holds a bounded number of intermediate keys, so large ranges
+// do not need to be held in memory.
+func ExportPublicKeys(out io.Writer, deriver *KeyDeriver, first uint32, last uint32, format PublicKeyFormat) error {
+	if deriver == nil {
+		return errors.New("no key deriver supplied")
+	}
+	if last < first {
+		return errors.New("last account before first account")
+	}
+
+	var writer publicKeyWriter
+	switch format {
+	case PublicKeyFormatJSON:
+		writer = &jsonPublicKeyWriter{out: out}
+	case PublicKeyFormatCSV:
+		writer = &csvPublicKeyWriter{out: csv.NewWriter(out)}
+	case PublicKeyFormatSSZ:
+		writer = &sszPublicKeyWriter{out: out}
+	default:
+		return fmt.Errorf("unsupported format %d", format)
+	}
+
+	if err := writer.start(); err != nil {
+		return err
+	}
+	for account := uint64(first); account <= uint64(last); account++ {
+		keys, err := deriver.AccountPublicKeys(uint32(account))
+		if err != nil {
+			return err
+		}
+		if err := writer.write(keys); err != nil {
+			return errors.Wrapf(err, "failed to write keys for account %d", account)
+		}
+	}
+
+	return writer.finish()
+}
+
+// publicKeyWriter writes account public keys in a given format.
+type publicKeyWriter interface {
+	start() error
+	write(keys *AccountPublicKeys) error
+	finish() error
+}
+
+type jsonPublicKeyWriter struct {
+	out     io.Writer
+	written bool
+}
+
+func (w *jsonPublicKeyWriter) start() error {
+	_, err := io.WriteString(w.out, "[")
+
+	return err
+}
+
+func (w *jsonPublicKeyWriter) write(keys *AccountPublicKeys) error {
+	data, err := json.Marshal(&accountPublicKeysJSON{
+		Account:               strconv.FormatUint(uint64(keys.Account), 10),
+		SigningPath:           keys.SigningPath,
+		SigningPublicKey:      fmt.Sprintf("%#x", keys.SigningPublicKey),
+		WithdrawalPath:        keys.WithdrawalPath,
+		WithdrawalPublicKey:   fmt.Sprintf("%#x", keys.WithdrawalPublicKey),
+		WithdrawalCredentials: fmt.Sprintf("%#x", keys.WithdrawalCredentials),
+	})
+	if err != nil {
+		return err
+	}
+	if w.written {
+		if _, err := io.WriteString(w.out, ","); err != nil {
+			return err
+		}
+	}
+	w.written = true
+	_, err = w.out.Write(data)
+
+	return err
+}
+
+func (w *jsonPublicKeyWriter) finish() error {
+	_, err := io.WriteString(w.out, "]\n")
+
+	return err
+}
+
+type csvPublicKeyWriter struct {
+	out *csv.Writer
+}
+
+func (w *csvPublicKeyWriter) start() error {
+	return w.out.Write([]string{
+		"account",
+		"signing_path",
+		"signing_pubkey",
+		"withdrawal_path",
+		"withdrawal_pubkey",
+		"withdrawal_credentials",
+	})
+}
+
+func (w *csvPublicKeyWriter) write(keys *AccountPublicKeys) error {
+	return w.out.Write([]string{
+		strconv.FormatUint(uint64(keys.Account), 10),
+		keys.SigningPath,
+		fmt.Sprintf("%#x", keys.SigningPublicKey),
+		keys.WithdrawalPath,
+		fmt.Sprintf("%#x", keys.WithdrawalPublicKey),
+		fmt.Sprintf("%#x", keys.WithdrawalCredentials),
+	})
+}
+
+func (w *csvPublicKeyWriter) finish() error {
+	w.out.Flush()
+
+	return w.out.Error()
+}
+
+type sszPublicKeyWriter struct {
+	out io.Writer
+}
+
+func (*sszPublicKeyWriter) start() error {
+	// A list of fixed-size containers is the concatenation of their serializations.
+	return nil
+}
+
+func (w *sszPublicKeyWriter) write(keys *AccountPublicKeys) error {
+	data := make([]byte, 0, 4+48+48+32)
+	data = append(data, uint64ToBytes(uint64(keys.Account))[:4]...)
+	data = append(data, keys.SigningPublicKey...)
+	data = append(data, keys.WithdrawalPublicKey...)
+	data = append(data, keys.WithdrawalCredentials...)
+	_, err := w.out.Write(data)
+
+	return err
+}
+
+func (*sszPublicKeyWriter) finish() error {
+	return nil
+}
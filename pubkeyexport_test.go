@@ -0,0 +1,115 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestAccountPublicKeys(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	deriver, err := util.NewKeyDeriver(seed)
+	require.NoError(t, err)
+
+	keys, err := deriver.AccountPublicKeys(0)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), keys.Account)
+	assert.Equal(t, "m/12381/3600/0/0/0", keys.SigningPath)
+	assert.Equal(t, _byteArray("b3d758f5ff8d1bdfe4b744e2372b5f37261619f4a45c97db829675e4669732781c858caaec6dbe86c2d096070de5c992"), keys.SigningPublicKey)
+	assert.Equal(t, "m/12381/3600/0/0", keys.WithdrawalPath)
+	withdrawalKey, err := util.PublicKeyFromSeedAndPath(seed, "m/12381/3600/0/0")
+	require.NoError(t, err)
+	assert.Equal(t, withdrawalKey.Marshal(), keys.WithdrawalPublicKey)
+	withdrawalCredentials, err := util.BLSWithdrawalCredentials(withdrawalKey.Marshal())
+	require.NoError(t, err)
+	assert.Equal(t, withdrawalCredentials, keys.WithdrawalCredentials)
+}
+
+func TestExportPublicKeys(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	deriver, err := util.NewKeyDeriver(seed)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.EqualError(t, util.ExportPublicKeys(&out, nil, 0, 1, util.PublicKeyFormatJSON), "no key deriver supplied")
+	require.EqualError(t, util.ExportPublicKeys(&out, deriver, 2, 1, util.PublicKeyFormatJSON), "last account before first account")
+	require.EqualError(t, util.ExportPublicKeys(&out, deriver, 0, 1, util.PublicKeyFormat(99)), "unsupported format 99")
+
+	expected := make([]*util.AccountPublicKeys, 0, 3)
+	for account := uint32(5); account <= 7; account++ {
+		keys, err := deriver.AccountPublicKeys(account)
+		require.NoError(t, err)
+		expected = append(expected, keys)
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, util.ExportPublicKeys(&out, deriver, 5, 7, util.PublicKeyFormatJSON))
+		var records []map[string]string
+		require.NoError(t, json.Unmarshal(out.Bytes(), &records))
+		require.Len(t, records, 3)
+		for i, keys := range expected {
+			assert.Equal(t, fmt.Sprintf("%d", keys.Account), records[i]["account"])
+			assert.Equal(t, keys.SigningPath, records[i]["signing_path"])
+			assert.Equal(t, fmt.Sprintf("%#x", keys.SigningPublicKey), records[i]["signing_pubkey"])
+			assert.Equal(t, keys.WithdrawalPath, records[i]["withdrawal_path"])
+			assert.Equal(t, fmt.Sprintf("%#x", keys.WithdrawalPublicKey), records[i]["withdrawal_pubkey"])
+			assert.Equal(t, fmt.Sprintf("%#x", keys.WithdrawalCredentials), records[i]["withdrawal_credentials"])
+		}
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, util.ExportPublicKeys(&out, deriver, 5, 7, util.PublicKeyFormatCSV))
+		records, err := csv.NewReader(&out).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+		assert.Equal(t, []string{"account", "signing_path", "signing_pubkey", "withdrawal_path", "withdrawal_pubkey", "withdrawal_credentials"}, records[0])
+		for i, keys := range expected {
+			assert.Equal(t, []string{
+				fmt.Sprintf("%d", keys.Account),
+				keys.SigningPath,
+				fmt.Sprintf("%#x", keys.SigningPublicKey),
+				keys.WithdrawalPath,
+				fmt.Sprintf("%#x", keys.WithdrawalPublicKey),
+				fmt.Sprintf("%#x", keys.WithdrawalCredentials),
+			}, records[i+1])
+		}
+	})
+
+	t.Run("SSZ", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, util.ExportPublicKeys(&out, deriver, 5, 7, util.PublicKeyFormatSSZ))
+		data := out.Bytes()
+		require.Len(t, data, 3*132)
+		for i, keys := range expected {
+			record := data[i*132 : (i+1)*132]
+			assert.Equal(t, keys.Account, binary.LittleEndian.Uint32(record[0:4]))
+			assert.Equal(t, keys.SigningPublicKey, record[4:52])
+			assert.Equal(t, keys.WithdrawalPublicKey, record[52:100])
+			assert.Equal(t, keys.WithdrawalCredentials, record[100:132])
+		}
+	})
+}
@@ -0,0 +1,334 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+// recoveryBatchSize is the number of candidate mnemonics checked between progress reports.
+const recoveryBatchSize = 4096
+
+// ErrMnemonicNotFound is returned when no candidate mnemonic matches the known keys.
+var ErrMnemonicNotFound = errors.New("mnemonic not found")
+
+// MnemonicRecovery describes a partially-known mnemonic to recover.
+type MnemonicRecovery struct {
+	// Words are the words of the mnemonic.
+	// Unknown words are supplied as "" or "?".  Words that are not in the BIP-39 word list are treated as
+	// misspelt, and are replaced by the words within MaxEditDistance of them.
+	Words []string
+	// Passphrase is the passphrase of the mnemonic.
+	Passphrase string
+	// PublicKeys are known signing or withdrawal public keys derived from the mnemonic.
+	PublicKeys [][]byte
+	// WithdrawalCredentials are known BLS withdrawal credentials derived from the mnemonic.
+	WithdrawalCredentials [][]byte
+	// Accounts is the number of accounts, starting from 0, whose keys are checked against the known keys.
+	// It defaults to 1.
+	Accounts uint32
+	// MaxEditDistance is the maximum edit distance between a misspelt word and its candidates.
+	// It defaults to 2.
+	MaxEditDistance int
+	// Progress, if supplied, is called periodically with the number of candidates checked and the total.
+	Progress func(checked uint64, total uint64)
+}
+
+// RecoveredMnemonic is a mnemonic found by RecoverMnemonic.
+type RecoveredMnemonic struct {
+	Mnemonic string
+	// Path is the path of the key that matched a known public key or withdrawal credentials.
+	Path string
+}
+
+// RecoverMnemonic recovers a mnemonic with unknown or misspelt words.
+// Candidate mnemonics that pass the BIP-39 checksum are checked in parallel by deriving the signing and withdrawal
+// keys of their accounts, and the first whose keys match a known public key or withdrawal credentials is returned.
+// ErrMnemonicNotFound is returned if no candidate matches.
+func RecoverMnemonic(ctx context.Context, recovery *MnemonicRecovery) (*RecoveredMnemonic, error) {
+	candidates, total, err := recoveryCandidates(recovery)
+	if err != nil {
+		return nil, err
+	}
+	matcher := newRecoveryMatcher(recovery)
+
+	searchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batches := make(chan uint64)
+	go func() {
+		defer close(batches)
+		for start := uint64(0); start < total; start += recoveryBatchSize {
+			select {
+			case batches <- start:
+			case <-searchCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var checked atomic.Uint64
+	var progressMu sync.Mutex
+	var once sync.Once
+	var res *RecoveredMnemonic
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			indices := make([]int, len(candidates))
+			for start := range batches {
+				end := start + recoveryBatchSize
+				if end > total {
+					end = total
+				}
+				for n := start; n < end; n++ {
+					candidateIndices(candidates, n, indices)
+					if !mnemonicChecksumValid(indices) {
+						continue
+					}
+					if found := matcher.match(indices); found != nil {
+						once.Do(func() {
+							res = found
+							cancel()
+						})
+
+						return
+					}
+				}
+				count := checked.Add(end - start)
+				if recovery.Progress != nil {
+					progressMu.Lock()
+					recovery.Progress(count, total)
+					progressMu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	switch {
+	case res != nil:
+		return res, nil
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	default:
+		return nil, ErrMnemonicNotFound
+	}
+}
+
+// recoveryCandidates returns the candidate word indices for each position of the mnemonic, and the total number
+// of candidate mnemonics.
+func recoveryCandidates(recovery *MnemonicRecovery) ([][]int, uint64, error) {
+	if recovery == nil {
+		return nil, 0, errors.New("no recovery supplied")
+	}
+	switch len(recovery.Words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, 0, fmt.Errorf("mnemonic must have 12, 15, 18, 21 or 24 words, not %d", len(recovery.Words))
+	}
+	if len(recovery.PublicKeys) == 0 && len(recovery.WithdrawalCredentials) == 0 {
+		return nil, 0, errors.New("no public keys or withdrawal credentials supplied")
+	}
+	maxEditDistance := recovery.MaxEditDistance
+	if maxEditDistance == 0 {
+		maxEditDistance = 2
+	}
+
+	wordList := bip39.GetWordList()
+	candidates := make([][]int, len(recovery.Words))
+	total := uint64(1)
+	for i, word := range recovery.Words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		switch {
+		case word == "" || word == "?":
+			candidates[i] = make([]int, len(wordList))
+			for j := range wordList {
+				candidates[i][j] = j
+			}
+		default:
+			if index, exists := bip39.GetWordIndex(word); exists {
+				candidates[i] = []int{index}
+
+				break
+			}
+			for j := range wordList {
+				if editDistance(word, wordList[j]) <= maxEditDistance {
+					candidates[i] = append(candidates[i], j)
+				}
+			}
+			if len(candidates[i]) == 0 {
+				return nil, 0, fmt.Errorf("no candidates for word %d %q", i+1, word)
+			}
+		}
+		if total > math.MaxUint64/uint64(len(candidates[i])) {
+			return nil, 0, errors.New("too many candidate mnemonics")
+		}
+		total *= uint64(len(candidates[i]))
+	}
+
+	return candidates, total, nil
+}
+
+// candidateIndices sets the word indices of the nth candidate mnemonic.
+func candidateIndices(candidates [][]int, n uint64, indices []int) {
+	for i := len(candidates) - 1; i >= 0; i-- {
+		count := uint64(len(candidates[i]))
+		indices[i] = candidates[i][n%count]
+		n /= count
+	}
+}
+
+// mnemonicChecksumValid returns true if the mnemonic with the given word indices passes the BIP-39 checksum.
+func mnemonicChecksumValid(indices []int) bool {
+	checksumBits := len(indices) / 3
+	entropyBits := len(indices)*11 - checksumBits
+	data := make([]byte, (len(indices)*11+7)/8)
+	for i, index := range indices {
+		for bit := 0; bit < 11; bit++ {
+			if index&(1<<(10-bit)) != 0 {
+				pos := i*11 + bit
+				data[pos/8] |= 0x80 >> (pos % 8)
+			}
+		}
+	}
+	hash := sha256.Sum256(data[:entropyBits/8])
+	for bit := 0; bit < checksumBits; bit++ {
+		pos := entropyBits + bit
+		if (data[pos/8]&(0x80>>(pos%8)) != 0) != (hash[bit/8]&(0x80>>(bit%8)) != 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// recoveryMatcher matches the keys of candidate mnemonics against the known keys.
+type recoveryMatcher struct {
+	wordList              []string
+	passphrase            string
+	accounts              uint32
+	publicKeys            [][]byte
+	withdrawalCredentials [][]byte
+}
+
+func newRecoveryMatcher(recovery *MnemonicRecovery) *recoveryMatcher {
+	accounts := recovery.Accounts
+	if accounts == 0 {
+		accounts = 1
+	}
+
+	return &recoveryMatcher{
+		wordList:              bip39.GetWordList(),
+		passphrase:            recovery.Passphrase,
+		accounts:              accounts,
+		publicKeys:            recovery.PublicKeys,
+		withdrawalCredentials: recovery.WithdrawalCredentials,
+	}
+}
+
+// match returns the recovered mnemonic if the mnemonic with the given word indices matches a known key.
+func (m *recoveryMatcher) match(indices []int) *RecoveredMnemonic {
+	words := make([]string, len(indices))
+	for i, index := range indices {
+		words[i] = m.wordList[index]
+	}
+	mnemonic := strings.Join(words, " ")
+	// The keys of all accounts share the ERC-2334 prefix, and each withdrawal key is the parent of its signing key,
+	// so they are derived through a single deriver to avoid repeating work.
+	deriver, err := NewKeyDeriver(bip39.NewSeed(mnemonic, m.passphrase))
+	if err != nil {
+		return nil
+	}
+
+	for account := uint32(0); account < m.accounts; account++ {
+		for _, path := range []string{
+			SigningPath(account),
+			WithdrawalPath(account),
+		} {
+			key, err := deriver.PublicKey(path)
+			if err != nil {
+				continue
+			}
+			pubKey := key.Marshal()
+			for i := range m.publicKeys {
+				if bytes.Equal(pubKey, m.publicKeys[i]) {
+					return &RecoveredMnemonic{Mnemonic: mnemonic, Path: path}
+				}
+			}
+			if len(m.withdrawalCredentials) == 0 {
+				continue
+			}
+			withdrawalCredentials, err := BLSWithdrawalCredentials(pubKey)
+			if err != nil {
+				continue
+			}
+			for i := range m.withdrawalCredentials {
+				if bytes.Equal(withdrawalCredentials, m.withdrawalCredentials[i]) {
+					return &RecoveredMnemonic{Mnemonic: mnemonic, Path: path}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// editDistance returns the Damerau-Levenshtein (optimal string alignment) distance between two strings.
+func editDistance(a string, b string) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = minInt(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,181 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+const recoveryMnemonic = "absurd avoid scissors anxiety gather lottery category door army half long cage bachelor another expect people blade school educate curtain scrub monitor lady beyond"
+
+func recoveryWords(replacements map[int]string) []string {
+	words := strings.Split(recoveryMnemonic, " ")
+	for i, word := range replacements {
+		words[i] = word
+	}
+
+	return words
+}
+
+func TestRecoverMnemonic(t *testing.T) {
+	signingPubKey := _byteArray("b246462a9c89bcca5ebc4ce2c95956b06851a17077bd0ce29556827966d8fd4fefe7a62bcf679449870deca82ed88b10")
+	withdrawalCredentials := _byteArray("0065efb9e1cfa538758efcbbc9cbd56226c973f0872ca360461919bd11ae36a3")
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		recovery *util.MnemonicRecovery
+		err      string
+		mnemonic string
+		path     string
+	}{
+		{
+			name: "Nil",
+			ctx:  context.Background(),
+			err:  "no recovery supplied",
+		},
+		{
+			name: "WordCountInvalid",
+			ctx:  context.Background(),
+			recovery: &util.MnemonicRecovery{
+				Words:      []string{"abandon", "?"},
+				PublicKeys: [][]byte{signingPubKey},
+			},
+			err: "mnemonic must have 12, 15, 18, 21 or 24 words, not 2",
+		},
+		{
+			name: "KeysMissing",
+			ctx:  context.Background(),
+			recovery: &util.MnemonicRecovery{
+				Words: recoveryWords(map[int]string{23: "?"}),
+			},
+			err: "no public keys or withdrawal credentials supplied",
+		},
+		{
+			name: "NoCandidates",
+			ctx:  context.Background(),
+			recovery: &util.MnemonicRecovery{
+				Words:      recoveryWords(map[int]string{3: "zzzzzzzz"}),
+				PublicKeys: [][]byte{signingPubKey},
+			},
+			err: `no candidates for word 4 "zzzzzzzz"`,
+		},
+		{
+			name: "TooManyCandidates",
+			ctx:  context.Background(),
+			recovery: &util.MnemonicRecovery{
+				Words:      make([]string, 24),
+				PublicKeys: [][]byte{signingPubKey},
+			},
+			err: "too many candidate mnemonics",
+		},
+		{
+			name: "LastWordMissing",
+			ctx:  context.Background(),
+			recovery: &util.MnemonicRecovery{
+				Words:      recoveryWords(map[int]string{23: "?"}),
+				PublicKeys: [][]byte{signingPubKey},
+			},
+			mnemonic: recoveryMnemonic,
+			path:     "m/12381/3600/0/0/0",
+		},
+		{
+			name: "MiddleWordMissing",
+			ctx:  context.Background(),
+			recovery: &util.MnemonicRecovery{
+				Words:      recoveryWords(map[int]string{10: ""}),
+				PublicKeys: [][]byte{signingPubKey},
+			},
+			mnemonic: recoveryMnemonic,
+			path:     "m/12381/3600/0/0/0",
+		},
+		{
+			name: "Misspelt",
+			ctx:  context.Background(),
+			recovery: &util.MnemonicRecovery{
+				Words:      recoveryWords(map[int]string{2: "scisors", 5: "lotery", 12: "Bachleor"}),
+				PublicKeys: [][]byte{signingPubKey},
+			},
+			mnemonic: recoveryMnemonic,
+			path:     "m/12381/3600/0/0/0",
+		},
+		{
+			name: "WithdrawalCredentials",
+			ctx:  context.Background(),
+			recovery: &util.MnemonicRecovery{
+				Words:                 recoveryWords(map[int]string{23: "?"}),
+				WithdrawalCredentials: [][]byte{withdrawalCredentials},
+				Accounts:              3,
+			},
+			mnemonic: recoveryMnemonic,
+			path:     "m/12381/3600/2/0",
+		},
+		{
+			name: "WithdrawalCredentialsBeyondAccounts",
+			ctx:  context.Background(),
+			recovery: &util.MnemonicRecovery{
+				Words:                 recoveryWords(map[int]string{23: "?"}),
+				WithdrawalCredentials: [][]byte{withdrawalCredentials},
+				Accounts:              2,
+			},
+			err: "mnemonic not found",
+		},
+		{
+			name: "Cancelled",
+			ctx:  cancelledCtx,
+			recovery: &util.MnemonicRecovery{
+				Words:      recoveryWords(map[int]string{0: "?", 23: "?"}),
+				PublicKeys: [][]byte{make([]byte, 48)},
+			},
+			err: "context canceled",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.RecoverMnemonic(test.ctx, test.recovery)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.mnemonic, res.Mnemonic)
+				assert.Equal(t, test.path, res.Path)
+			}
+		})
+	}
+}
+
+func TestRecoverMnemonicProgress(t *testing.T) {
+	var checked, total uint64
+	_, err := util.RecoverMnemonic(context.Background(), &util.MnemonicRecovery{
+		Words:      recoveryWords(map[int]string{23: "?"}),
+		PublicKeys: [][]byte{make([]byte, 48)},
+		Progress: func(c uint64, t uint64) {
+			checked, total = c, t
+		},
+	})
+	require.ErrorIs(t, err, util.ErrMnemonicNotFound)
+	assert.Equal(t, uint64(2048), total)
+	assert.Equal(t, uint64(2048), checked)
+}
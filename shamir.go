@@ -0,0 +1,269 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// keyShareVersion is the version of the serialised key share format.
+	keyShareVersion = 1
+	// seedChunkLen is the number of bytes of a seed held in each field element; it keeps each chunk below r.
+	seedChunkLen = 31
+	// keyShareHeaderLen is the length of the serialised key share header.
+	keyShareHeaderLen = 5
+	// keyShareChecksumLen is the length of the serialised key share checksum.
+	keyShareChecksumLen = 4
+)
+
+// KeyShare is a share of a secret split using Shamir's secret sharing over the BLS12-381 scalar field.
+type KeyShare struct {
+	// Threshold is the number of shares required to recover the secret.
+	Threshold uint8
+	// Index is the point at which the sharing polynomials were evaluated to create this share.
+	Index uint8
+	// Length is the length of the shared secret in bytes.
+	Length uint8
+	// Values are the evaluations of the sharing polynomials, one per field element of the secret.
+	Values []*big.Int
+}
+
+// SplitSeed splits a seed into shares, any threshold of which can recover the seed.
+func SplitSeed(seed []byte, threshold int, shares int) ([]*KeyShare, error) {
+	if len(seed) == 0 {
+		return nil, errors.New("no seed supplied")
+	}
+	if len(seed) > 255 {
+		return nil, errors.New("seed must be at most 255 bytes")
+	}
+
+	values := make([]*big.Int, 0, (len(seed)+seedChunkLen-1)/seedChunkLen)
+	for i := 0; i < len(seed); i += seedChunkLen {
+		end := i + seedChunkLen
+		if end > len(seed) {
+			end = len(seed)
+		}
+		values = append(values, osToIP(seed[i:end]))
+	}
+
+	return splitSecret(values, len(seed), threshold, shares)
+}
+
+// RecoverSeed recovers a seed from its shares.
+func RecoverSeed(shares []*KeyShare) ([]byte, error) {
+	values, length, err := recoverSecret(shares)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != (length+seedChunkLen-1)/seedChunkLen {
+		return nil, errors.New("shares are not of a seed")
+	}
+
+	seed := make([]byte, 0, length)
+	for i, value := range values {
+		chunkLen := seedChunkLen
+		if i == len(values)-1 && length%seedChunkLen != 0 {
+			chunkLen = length % seedChunkLen
+		}
+		if value.BitLen() > chunkLen*8 {
+			return nil, errors.New("shares do not recover a valid seed")
+		}
+		seed = append(seed, i2OSP(value, chunkLen)...)
+	}
+
+	return seed, nil
+}
+
+// Marshal serialises the key share.
+// The serialised form is version, threshold, index, length and value count, each as a single byte,
+// followed by each value as 32 bytes and a 4-byte checksum.
+func (s *KeyShare) Marshal() []byte {
+	data := make([]byte, 0, keyShareHeaderLen+32*len(s.Values)+keyShareChecksumLen)
+	data = append(data, keyShareVersion, s.Threshold, s.Index, s.Length, byte(len(s.Values)))
+	for _, value := range s.Values {
+		data = append(data, i2OSP(value, 32)...)
+	}
+
+	return append(data, SHA256(data)[:keyShareChecksumLen]...)
+}
+
+// KeyShareFromBytes deserialises a key share.
+func KeyShareFromBytes(data []byte) (*KeyShare, error) {
+	if len(data) < keyShareHeaderLen+keyShareChecksumLen {
+		return nil, errors.New("key share too short")
+	}
+	if data[0] != keyShareVersion {
+		return nil, fmt.Errorf("unsupported key share version %d", data[0])
+	}
+	if len(data) != keyShareHeaderLen+32*int(data[4])+keyShareChecksumLen {
+		return nil, errors.New("key share has incorrect length")
+	}
+	checksumStart := len(data) - keyShareChecksumLen
+	if !bytes.Equal(SHA256(data[:checksumStart])[:keyShareChecksumLen], data[checksumStart:]) {
+		return nil, errors.New("key share checksum mismatch")
+	}
+
+	share := &KeyShare{
+		Threshold: data[1],
+		Index:     data[2],
+		Length:    data[3],
+		Values:    make([]*big.Int, data[4]),
+	}
+	for i := range share.Values {
+		share.Values[i] = osToIP(data[keyShareHeaderLen+32*i : keyShareHeaderLen+32*(i+1)])
+		if share.Values[i].Cmp(r) >= 0 {
+			return nil, fmt.Errorf("key share value %d out of range", i)
+		}
+	}
+
+	return share, nil
+}
+
+// splitSecret splits a secret made up of field elements into shares.
+func splitSecret(secret []*big.Int, length int, threshold int, shares int) ([]*KeyShare, error) {
+	if threshold < 1 {
+		return nil, errors.New("threshold must be at least 1")
+	}
+	if shares < threshold {
+		return nil, errors.New("shares must be at least threshold")
+	}
+	if shares > 255 {
+		return nil, errors.New("shares must be at most 255")
+	}
+
+	res := make([]*KeyShare, shares)
+	for i := range res {
+		res[i] = &KeyShare{
+			Threshold: uint8(threshold),
+			Index:     uint8(i + 1),
+			Length:    uint8(length),
+			Values:    make([]*big.Int, len(secret)),
+		}
+	}
+	for i := range secret {
+		coefficients, err := randomPolynomial(secret[i], threshold)
+		if err != nil {
+			return nil, err
+		}
+		for j := range res {
+			res[j].Values[i] = evaluatePolynomial(coefficients, big.NewInt(int64(res[j].Index)))
+		}
+	}
+
+	return res, nil
+}
+
+// recoverSecret recovers a secret made up of field elements from its shares.
+func recoverSecret(shares []*KeyShare) ([]*big.Int, int, error) {
+	if len(shares) == 0 {
+		return nil, 0, errors.New("no shares supplied")
+	}
+	for i := range shares {
+		if shares[i] == nil {
+			return nil, 0, fmt.Errorf("share %d missing", i)
+		}
+	}
+	first := shares[0]
+	if len(shares) < int(first.Threshold) {
+		return nil, 0, fmt.Errorf("%d shares required but only %d supplied", first.Threshold, len(shares))
+	}
+
+	indices := make([]*big.Int, len(shares))
+	seen := make(map[uint8]bool, len(shares))
+	for i, share := range shares {
+		if share.Threshold != first.Threshold || share.Length != first.Length || len(share.Values) != len(first.Values) {
+			return nil, 0, fmt.Errorf("share %d does not match other shares", i)
+		}
+		if share.Index == 0 {
+			return nil, 0, fmt.Errorf("share %d has invalid index 0", i)
+		}
+		if seen[share.Index] {
+			return nil, 0, fmt.Errorf("duplicate share index %d", share.Index)
+		}
+		seen[share.Index] = true
+		indices[i] = big.NewInt(int64(share.Index))
+	}
+
+	values := make([]*big.Int, len(first.Values))
+	points := make([]*big.Int, len(shares))
+	for i := range values {
+		for j := range shares {
+			points[j] = shares[j].Values[i]
+		}
+		values[i] = interpolateAtZero(indices, points)
+	}
+
+	return values, int(first.Length), nil
+}
+
+// randomPolynomial generates a polynomial of the given number of coefficients with a fixed constant term.
+func randomPolynomial(constant *big.Int, coefficients int) ([]*big.Int, error) {
+	res := make([]*big.Int, coefficients)
+	res[0] = new(big.Int).Set(constant)
+	for i := 1; i < coefficients; i++ {
+		coefficient, err := rand.Int(rand.Reader, r)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate coefficient")
+		}
+		res[i] = coefficient
+	}
+
+	return res, nil
+}
+
+// evaluatePolynomial evaluates a polynomial at x modulo r.
+func evaluatePolynomial(coefficients []*big.Int, x *big.Int) *big.Int {
+	res := big.NewInt(0)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		res.Mul(res, x)
+		res.Add(res, coefficients[i])
+		res.Mod(res, r)
+	}
+
+	return res
+}
+
+// interpolateAtZero evaluates the polynomial passing through the given points at 0 modulo r.
+func interpolateAtZero(xs []*big.Int, ys []*big.Int) *big.Int {
+	res := big.NewInt(0)
+	for i := range xs {
+		res.Add(res, new(big.Int).Mul(ys[i], lagrangeCoefficient(xs, i)))
+		res.Mod(res, r)
+	}
+
+	return res
+}
+
+// lagrangeCoefficient calculates the Lagrange basis polynomial for the i'th point, evaluated at 0 modulo r.
+func lagrangeCoefficient(xs []*big.Int, i int) *big.Int {
+	numerator := big.NewInt(1)
+	denominator := big.NewInt(1)
+	for j := range xs {
+		if j == i {
+			continue
+		}
+		numerator.Mul(numerator, xs[j])
+		numerator.Mod(numerator, r)
+		denominator.Mul(denominator, new(big.Int).Sub(xs[j], xs[i]))
+		denominator.Mod(denominator, r)
+	}
+
+	return numerator.Mul(numerator, denominator.ModInverse(denominator, r)).Mod(numerator, r)
+}
@@ -0,0 +1,45 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// SplitKey splits a private key into shares, any threshold of which can recover the key.
+func SplitKey(sk *e2types.BLSPrivateKey, threshold int, shares int) ([]*KeyShare, error) {
+	if sk == nil {
+		return nil, errors.New("no key supplied")
+	}
+
+	return splitSecret([]*big.Int{osToIP(sk.Marshal())}, 32, threshold, shares)
+}
+
+// RecoverKey recovers a private key from its shares.
+func RecoverKey(shares []*KeyShare) (*e2types.BLSPrivateKey, error) {
+	values, length, err := recoverSecret(shares)
+	if err != nil {
+		return nil, err
+	}
+	if length != 32 || len(values) != 1 {
+		return nil, errors.New("shares are not of a private key")
+	}
+
+	return e2types.BLSPrivateKeyFromBytes(i2OSP(values[0], 32))
+}
@@ -0,0 +1,161 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestSplitKey(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	key, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		threshold int
+		shares    int
+		err       string
+	}{
+		{
+			name:      "ThresholdZero",
+			threshold: 0,
+			shares:    3,
+			err:       "threshold must be at least 1",
+		},
+		{
+			name:      "SharesBelowThreshold",
+			threshold: 3,
+			shares:    2,
+			err:       "shares must be at least threshold",
+		},
+		{
+			name:      "TooManyShares",
+			threshold: 3,
+			shares:    256,
+			err:       "shares must be at most 255",
+		},
+		{
+			name:      "OneOfOne",
+			threshold: 1,
+			shares:    1,
+		},
+		{
+			name:      "ThreeOfFive",
+			threshold: 3,
+			shares:    5,
+		},
+		{
+			name:      "FiveOfFive",
+			threshold: 5,
+			shares:    5,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shares, err := util.SplitKey(key, test.threshold, test.shares)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, shares, test.shares)
+
+			// Any threshold shares recover the key.
+			for i := 0; i+test.threshold <= len(shares); i++ {
+				recovered, err := util.RecoverKey(shares[i : i+test.threshold])
+				require.NoError(t, err)
+				assert.Equal(t, key.Marshal(), recovered.Marshal())
+			}
+
+			// Fewer than threshold shares are refused.
+			if test.threshold > 1 {
+				_, err = util.RecoverKey(shares[:test.threshold-1])
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSplitKeyNil(t *testing.T) {
+	_, err := util.SplitKey(nil, 2, 3)
+	require.EqualError(t, err, "no key supplied")
+}
+
+func TestRecoverKeyErrors(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	key, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	shares, err := util.SplitKey(key, 2, 3)
+	require.NoError(t, err)
+	seedShares, err := util.SplitSeed(seed, 2, 3)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		shares []*util.KeyShare
+		err    string
+	}{
+		{
+			name: "Nil",
+			err:  "no shares supplied",
+		},
+		{
+			name:   "Missing",
+			shares: []*util.KeyShare{shares[0], nil},
+			err:    "share 1 missing",
+		},
+		{
+			name:   "TooFew",
+			shares: shares[:1],
+			err:    "2 shares required but only 1 supplied",
+		},
+		{
+			name:   "Duplicate",
+			shares: []*util.KeyShare{shares[0], shares[0]},
+			err:    "duplicate share index 1",
+		},
+		{
+			name:   "ZeroIndex",
+			shares: []*util.KeyShare{{Threshold: 2, Length: 32, Values: []*big.Int{big.NewInt(1)}}, shares[0]},
+			err:    "share 0 has invalid index 0",
+		},
+		{
+			name:   "Mismatch",
+			shares: []*util.KeyShare{shares[0], seedShares[1]},
+			err:    "share 1 does not match other shares",
+		},
+		{
+			name:   "Seed",
+			shares: seedShares[:2],
+			err:    "shares are not of a private key",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := util.RecoverKey(test.shares)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
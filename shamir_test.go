@@ -0,0 +1,117 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestSplitSeed(t *testing.T) {
+	tests := []struct {
+		name string
+		seed []byte
+		err  string
+	}{
+		{
+			name: "Nil",
+			err:  "no seed supplied",
+		},
+		{
+			name: "TooLong",
+			seed: make([]byte, 256),
+			err:  "seed must be at most 255 bytes",
+		},
+		{
+			name: "Short",
+			seed: _byteArray("0102030405060708090a0b0c0d0e0f10"),
+		},
+		{
+			name: "SingleChunk",
+			seed: _byteArray("ff02030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1eff"),
+		},
+		{
+			name: "LeadingZeros",
+			seed: _byteArray("0000000000060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"),
+		},
+		{
+			name: "Long",
+			seed: _byteArray("52fdfc072182654f163f5f0f9a621d729566c74d10037c4d7bbb0407d1e2c64981855ad8681d0d86d1e91e00167939cb6694d2c422acd208a0072939487f6999"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shares, err := util.SplitSeed(test.seed, 2, 3)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+
+				return
+			}
+			require.NoError(t, err)
+			recovered, err := util.RecoverSeed(shares[1:])
+			require.NoError(t, err)
+			assert.Equal(t, test.seed, recovered)
+		})
+	}
+}
+
+func TestKeyShareMarshal(t *testing.T) {
+	seed := _byteArray("52fdfc072182654f163f5f0f9a621d729566c74d10037c4d7bbb0407d1e2c64981855ad8681d0d86d1e91e00167939cb6694d2c422acd208a0072939487f6999")
+	shares, err := util.SplitSeed(seed, 2, 2)
+	require.NoError(t, err)
+
+	data := shares[0].Marshal()
+	require.Len(t, data, 5+3*32+4)
+	share, err := util.KeyShareFromBytes(data)
+	require.NoError(t, err)
+	assert.Equal(t, shares[0], share)
+
+	tests := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{
+			name: "Short",
+			data: data[:8],
+			err:  "key share too short",
+		},
+		{
+			name: "Version",
+			data: append([]byte{0x02}, data[1:]...),
+			err:  "unsupported key share version 2",
+		},
+		{
+			name: "Length",
+			data: data[:len(data)-1],
+			err:  "key share has incorrect length",
+		},
+		{
+			name: "Checksum",
+			data: append(append([]byte{}, data[:len(data)-1]...), data[len(data)-1]^0x01),
+			err:  "key share checksum mismatch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := util.KeyShareFromBytes(test.data)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
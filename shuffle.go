@@ -0,0 +1,203 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ShuffleRoundCount is the number of rounds used by the swap-or-not shuffle.
+	ShuffleRoundCount = 90
+	// SyncCommitteeSize is the number of validators in a sync committee.
+	SyncCommitteeSize = 512
+	// MaxEffectiveBalance is the maximum effective balance of a validator prior to Electra, in Gwei.
+	MaxEffectiveBalance = uint64(32_000_000_000)
+	// MaxEffectiveBalanceElectra is the maximum effective balance of a validator from Electra, in Gwei.
+	MaxEffectiveBalanceElectra = uint64(2_048_000_000_000)
+
+	maxRandomByte  = uint64(1<<8 - 1)
+	maxRandomValue = uint64(1<<16 - 1)
+)
+
+// ComputeShuffledIndex returns the shuffled index for the given index.
+// Follows compute_shuffled_index in the consensus specification.
+func ComputeShuffledIndex(index uint64, indexCount uint64, seed []byte) (uint64, error) {
+	if len(seed) != 32 {
+		return 0, errors.New("seed must be 32 bytes")
+	}
+	if index >= indexCount {
+		return 0, fmt.Errorf("index %d out of range for %d indices", index, indexCount)
+	}
+
+	buf := make([]byte, 32+1+4)
+	copy(buf, seed)
+	for round := 0; round < ShuffleRoundCount; round++ {
+		buf[32] = byte(round)
+		pivot := binary.LittleEndian.Uint64(SHA256(buf[:33])[:8]) % indexCount
+		flip := (pivot + indexCount - index) % indexCount
+		position := index
+		if flip > position {
+			position = flip
+		}
+		binary.LittleEndian.PutUint32(buf[33:], uint32(position/256))
+		source := SHA256(buf)
+		bit := (source[(position%256)/8] >> (position % 8)) % 2
+		if bit == 1 {
+			index = flip
+		}
+	}
+
+	return index, nil
+}
+
+// ComputeProposerIndex selects a proposer from the active indices, weighted by effective balance.
+// effectiveBalances is indexed by validator index.
+// Follows compute_proposer_index in the consensus specification prior to Electra.
+func ComputeProposerIndex(seed []byte, activeIndices []uint64, effectiveBalances []uint64) (uint64, error) {
+	return computeProposerIndex(seed, activeIndices, effectiveBalances, randomByte, maxRandomByte, MaxEffectiveBalance)
+}
+
+// ComputeProposerIndexElectra selects a proposer from the active indices, weighted by effective balance.
+// effectiveBalances is indexed by validator index.
+// Follows compute_proposer_index in the consensus specification from Electra.
+func ComputeProposerIndexElectra(seed []byte, activeIndices []uint64, effectiveBalances []uint64) (uint64, error) {
+	return computeProposerIndex(seed, activeIndices, effectiveBalances, randomValue, maxRandomValue, MaxEffectiveBalanceElectra)
+}
+
+// GetNextSyncCommitteeIndices selects the members of a sync committee from the active indices,
+// weighted by effective balance.  Validators may appear more than once.
+// effectiveBalances is indexed by validator index.
+// Follows get_next_sync_committee_indices in the consensus specification prior to Electra.
+func GetNextSyncCommitteeIndices(seed []byte, activeIndices []uint64, effectiveBalances []uint64) ([]uint64, error) {
+	return getNextSyncCommitteeIndices(seed, activeIndices, effectiveBalances, randomByte, maxRandomByte, MaxEffectiveBalance)
+}
+
+// GetNextSyncCommitteeIndicesElectra selects the members of a sync committee from the active indices,
+// weighted by effective balance.  Validators may appear more than once.
+// effectiveBalances is indexed by validator index.
+// Follows get_next_sync_committee_indices in the consensus specification from Electra.
+func GetNextSyncCommitteeIndicesElectra(seed []byte, activeIndices []uint64, effectiveBalances []uint64) ([]uint64, error) {
+	return getNextSyncCommitteeIndices(seed, activeIndices, effectiveBalances, randomValue, maxRandomValue, MaxEffectiveBalanceElectra)
+}
+
+// randomSource provides the i'th random value for balance-weighted selection.
+type randomSource func(seed []byte, i uint64) uint64
+
+// randomByte provides an 8-bit random value, as used prior to Electra.
+func randomByte(seed []byte, i uint64) uint64 {
+	return uint64(SHA256(seed, uint64ToBytes(i/32))[i%32])
+}
+
+// randomValue provides a 16-bit random value, as used from Electra.
+func randomValue(seed []byte, i uint64) uint64 {
+	offset := (i % 16) * 2
+
+	return uint64(binary.LittleEndian.Uint16(SHA256(seed, uint64ToBytes(i/16))[offset : offset+2]))
+}
+
+func computeProposerIndex(seed []byte,
+	activeIndices []uint64,
+	effectiveBalances []uint64,
+	random randomSource,
+	maxRandom uint64,
+	maxEffectiveBalance uint64,
+) (
+	uint64,
+	error,
+) {
+	if err := checkSelectable(activeIndices, effectiveBalances); err != nil {
+		return 0, err
+	}
+
+	total := uint64(len(activeIndices))
+	for i := uint64(0); ; i++ {
+		candidate, err := selectCandidate(seed, activeIndices, effectiveBalances, i%total)
+		if err != nil {
+			return 0, err
+		}
+		if effectiveBalances[candidate]*maxRandom >= maxEffectiveBalance*random(seed, i) {
+			return candidate, nil
+		}
+	}
+}
+
+func getNextSyncCommitteeIndices(seed []byte,
+	activeIndices []uint64,
+	effectiveBalances []uint64,
+	random randomSource,
+	maxRandom uint64,
+	maxEffectiveBalance uint64,
+) (
+	[]uint64,
+	error,
+) {
+	if err := checkSelectable(activeIndices, effectiveBalances); err != nil {
+		return nil, err
+	}
+
+	total := uint64(len(activeIndices))
+	indices := make([]uint64, 0, SyncCommitteeSize)
+	for i := uint64(0); len(indices) < SyncCommitteeSize; i++ {
+		candidate, err := selectCandidate(seed, activeIndices, effectiveBalances, i%total)
+		if err != nil {
+			return nil, err
+		}
+		if effectiveBalances[candidate]*maxRandom >= maxEffectiveBalance*random(seed, i) {
+			indices = append(indices, candidate)
+		}
+	}
+
+	return indices, nil
+}
+
+// checkSelectable ensures that at least one active validator can be selected,
+// as otherwise balance-weighted selection would never terminate.
+func checkSelectable(activeIndices []uint64, effectiveBalances []uint64) error {
+	if len(activeIndices) == 0 {
+		return errors.New("no active indices")
+	}
+	for _, index := range activeIndices {
+		if index < uint64(len(effectiveBalances)) && effectiveBalances[index] > 0 {
+			return nil
+		}
+	}
+
+	return errors.New("no active validator has an effective balance")
+}
+
+// selectCandidate returns the validator index at the shuffled position of the active indices.
+func selectCandidate(seed []byte, activeIndices []uint64, effectiveBalances []uint64, index uint64) (uint64, error) {
+	shuffledIndex, err := ComputeShuffledIndex(index, uint64(len(activeIndices)), seed)
+	if err != nil {
+		return 0, err
+	}
+	candidate := activeIndices[shuffledIndex]
+	if candidate >= uint64(len(effectiveBalances)) {
+		return 0, fmt.Errorf("no effective balance for validator %d", candidate)
+	}
+
+	return candidate, nil
+}
+
+// uint64ToBytes returns the 8-byte little-endian representation of a uint64.
+func uint64ToBytes(val uint64) []byte {
+	res := make([]byte, 8)
+	binary.LittleEndian.PutUint64(res, val)
+
+	return res
+}
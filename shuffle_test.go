@@ -0,0 +1,279 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func _indices(count uint64) []uint64 {
+	res := make([]uint64, count)
+	for i := range res {
+		res[i] = uint64(i)
+	}
+
+	return res
+}
+
+func _balances(count uint64, balance func(uint64) uint64) []uint64 {
+	res := make([]uint64, count)
+	for i := range res {
+		res[i] = balance(uint64(i))
+	}
+
+	return res
+}
+
+func TestComputeShuffledIndex(t *testing.T) {
+	seed := _byteArray("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+
+	tests := []struct {
+		name       string
+		index      uint64
+		indexCount uint64
+		seed       []byte
+		err        string
+		res        uint64
+	}{
+		{
+			name:       "SeedMissing",
+			indexCount: 10,
+			err:        "seed must be 32 bytes",
+		},
+		{
+			name:       "SeedShort",
+			indexCount: 10,
+			seed:       seed[:31],
+			err:        "seed must be 32 bytes",
+		},
+		{
+			name:       "IndexOutOfRange",
+			index:      10,
+			indexCount: 10,
+			seed:       seed,
+			err:        "index 10 out of range for 10 indices",
+		},
+		{
+			name:       "Single",
+			indexCount: 1,
+			seed:       seed,
+			res:        0,
+		},
+		{
+			name:       "Good",
+			index:      4,
+			indexCount: 10,
+			seed:       seed,
+			res:        9,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.ComputeShuffledIndex(test.index, test.indexCount, test.seed)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestComputeShuffledIndexPermutation(t *testing.T) {
+	seed := _byteArray("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+
+	expected := []uint64{5, 2, 3, 1, 9, 6, 7, 4, 0, 8}
+	for i := range expected {
+		res, err := util.ComputeShuffledIndex(uint64(i), uint64(len(expected)), seed)
+		require.NoError(t, err)
+		assert.Equal(t, expected[i], res)
+	}
+
+	// Larger index counts cross multiple 256-bit source boundaries.
+	seen := make(map[uint64]bool)
+	for i := uint64(0); i < 1000; i++ {
+		res, err := util.ComputeShuffledIndex(i, 1000, seed)
+		require.NoError(t, err)
+		require.False(t, seen[res], "duplicate shuffled index %d", res)
+		seen[res] = true
+	}
+}
+
+func TestComputeProposerIndex(t *testing.T) {
+	seed := _byteArray("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	mixed := _balances(100, func(i uint64) uint64 {
+		if i < 50 {
+			return 32_000_000_000
+		}
+
+		return 16_000_000_000
+	})
+	compounding := _balances(100, func(i uint64) uint64 {
+		if i%10 == 0 {
+			return 2_048_000_000_000
+		}
+
+		return 32_000_000_000
+	})
+
+	tests := []struct {
+		name              string
+		activeIndices     []uint64
+		effectiveBalances []uint64
+		electra           bool
+		err               string
+		res               uint64
+	}{
+		{
+			name:              "NoActiveIndices",
+			effectiveBalances: mixed,
+			err:               "no active indices",
+		},
+		{
+			name:              "NoBalances",
+			activeIndices:     _indices(10),
+			effectiveBalances: make([]uint64, 10),
+			err:               "no active validator has an effective balance",
+		},
+		{
+			name:              "MissingBalance",
+			activeIndices:     []uint64{0, 200},
+			effectiveBalances: []uint64{1},
+			err:               "no effective balance for validator 200",
+		},
+		{
+			name:              "Single",
+			activeIndices:     []uint64{5},
+			effectiveBalances: mixed,
+			res:               5,
+		},
+		{
+			name:              "Phase0",
+			activeIndices:     _indices(100),
+			effectiveBalances: mixed,
+			res:               7,
+		},
+		{
+			name:              "Electra",
+			activeIndices:     _indices(100),
+			effectiveBalances: mixed,
+			electra:           true,
+			res:               92,
+		},
+		{
+			name:              "ElectraCompounding",
+			activeIndices:     _indices(100),
+			effectiveBalances: compounding,
+			electra:           true,
+			res:               92,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res uint64
+			var err error
+			if test.electra {
+				res, err = util.ComputeProposerIndexElectra(seed, test.activeIndices, test.effectiveBalances)
+			} else {
+				res, err = util.ComputeProposerIndex(seed, test.activeIndices, test.effectiveBalances)
+			}
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.res, res)
+			}
+		})
+	}
+}
+
+func TestGetNextSyncCommitteeIndices(t *testing.T) {
+	seed := _byteArray("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	mixed := _balances(100, func(i uint64) uint64 {
+		if i < 50 {
+			return 32_000_000_000
+		}
+
+		return 16_000_000_000
+	})
+	compounding := _balances(100, func(i uint64) uint64 {
+		if i%10 == 0 {
+			return 2_048_000_000_000
+		}
+
+		return 32_000_000_000
+	})
+
+	tests := []struct {
+		name              string
+		activeIndices     []uint64
+		effectiveBalances []uint64
+		electra           bool
+		err               string
+		first             []uint64
+		sum               uint64
+	}{
+		{
+			name:              "NoActiveIndices",
+			effectiveBalances: mixed,
+			err:               "no active indices",
+		},
+		{
+			name:              "Phase0",
+			activeIndices:     _indices(100),
+			effectiveBalances: mixed,
+			first:             []uint64{7, 55, 90, 1, 17, 10, 38, 93},
+			sum:               21282,
+		},
+		{
+			name:              "Electra",
+			activeIndices:     _indices(100),
+			effectiveBalances: compounding,
+			electra:           true,
+			first:             []uint64{92, 90, 10, 70, 38, 0, 40, 20},
+			sum:               23603,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res []uint64
+			var err error
+			if test.electra {
+				res, err = util.GetNextSyncCommitteeIndicesElectra(seed, test.activeIndices, test.effectiveBalances)
+			} else {
+				res, err = util.GetNextSyncCommitteeIndices(seed, test.activeIndices, test.effectiveBalances)
+			}
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, util.SyncCommitteeSize)
+				assert.Equal(t, test.first, res[:len(test.first)])
+				sum := uint64(0)
+				for _, index := range res {
+					sum += index
+				}
+				assert.Equal(t, test.sum, sum)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Domain types used by pure-Go signing, as defined in go-eth2-types which is not available without cgo.
+//
+//nolint:gochecknoglobals
+var (
+	domainBeaconProposer = [4]byte{0x00, 0x00, 0x00, 0x00}
+	domainBeaconAttester = [4]byte{0x01, 0x00, 0x00, 0x00}
+	domainDeposit        = [4]byte{0x03, 0x00, 0x00, 0x00}
+	domainVoluntaryExit  = [4]byte{0x04, 0x00, 0x00, 0x00}
+)
+
+// DomainApplicationBuilder is the domain type of builder API messages such as validator registrations, which are
+// signed with the genesis fork version and a zero genesis validators root.
+//
+//nolint:gochecknoglobals
+var DomainApplicationBuilder = [4]byte{0x00, 0x00, 0x00, 0x01}
+
+// ComputeSigningRoot computes the signing root of an object given its hash tree root and the signing domain.
+// Follows compute_signing_root in the consensus specification.
+func ComputeSigningRoot(objectRoot []byte, domain []byte) ([]byte, error) {
+	if len(objectRoot) != 32 {
+		return nil, errors.New("object root must be 32 bytes")
+	}
+	if len(domain) != 32 {
+		return nil, errors.New("domain must be 32 bytes")
+	}
+
+	// The signing data container has two 32-byte fields, so its root is the hash of their concatenation.
+	return SHA256(objectRoot, domain), nil
+}
+
+// SignRoot signs an object root with the given domain using a key from any BLS backend.
+// The signing helpers such as SignBeaconBlockHeader use this with the default backend; keys from other backends
+// can sign the roots of the same objects directly.
+func SignRoot(key BLSPrivateKey,
+	objectRoot []byte,
+	domainType [4]byte,
+	forkVersion []byte,
+	genesisValidatorsRoot []byte,
+) (
+	BLSSignature,
+	error,
+) {
+	if key == nil {
+		return nil, errors.New("no key supplied")
+	}
+	signingRoot, err := computeSigningRoot(objectRoot, domainType, forkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.Sign(signingRoot), nil
+}
+
+// computeSigningRoot computes the signing root of an object root with the given domain.
+func computeSigningRoot(objectRoot []byte,
+	domainType [4]byte,
+	forkVersion []byte,
+	genesisValidatorsRoot []byte,
+) (
+	[]byte,
+	error,
+) {
+	domain, err := computeDomain(domainType, forkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute domain")
+	}
+
+	return ComputeSigningRoot(objectRoot, domain)
+}
+
+// computeDomain computes a domain.
+// Follows compute_domain in the consensus specification.
+func computeDomain(domainType [4]byte, forkVersion []byte, genesisValidatorsRoot []byte) ([]byte, error) {
+	if len(forkVersion) != 4 {
+		return nil, errors.New("fork version must be 4 bytes in length")
+	}
+	if len(genesisValidatorsRoot) != 32 {
+		return nil, errors.New("genesis validators root must be 32 bytes in length")
+	}
+
+	// The fork data container has two fields that each fit in a chunk, so its root is the hash of their concatenation.
+	versionChunk := make([]byte, 32)
+	copy(versionChunk, forkVersion)
+	forkDataRoot := SHA256(versionChunk, genesisValidatorsRoot)
+
+	res := make([]byte, 32)
+	copy(res[0:4], domainType[:])
+	copy(res[4:32], forkDataRoot[:28])
+
+	return res, nil
+}
+
+// uint64Root returns the hash tree root of a uint64.
+func uint64Root(val uint64) []byte {
+	res := make([]byte, 32)
+	copy(res, uint64ToBytes(val))
+
+	return res
+}
@@ -0,0 +1,42 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// signRoot signs an object root with the given domain.
+func signRoot(key *e2types.BLSPrivateKey,
+	objectRoot []byte,
+	domainType e2types.DomainType,
+	forkVersion []byte,
+	genesisValidatorsRoot []byte,
+) (
+	e2types.Signature,
+	error,
+) {
+	if key == nil {
+		return nil, errors.New("no key supplied")
+	}
+	sig, err := SignRoot(HerumiPrivateKey(key), objectRoot, domainType, forkVersion, genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return sig.(*herumiSignature).sig, nil
+}
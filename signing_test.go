@@ -0,0 +1,62 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestComputeSigningRoot(t *testing.T) {
+	tests := []struct {
+		name       string
+		objectRoot []byte
+		domain     []byte
+		err        string
+		root       []byte
+	}{
+		{
+			name:   "ObjectRootMissing",
+			domain: make([]byte, 32),
+			err:    "object root must be 32 bytes",
+		},
+		{
+			name:       "DomainShort",
+			objectRoot: make([]byte, 32),
+			domain:     make([]byte, 31),
+			err:        "domain must be 32 bytes",
+		},
+		{
+			name:       "Good",
+			objectRoot: make([]byte, 32),
+			domain:     make([]byte, 32),
+			root:       _byteArray("f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a92759fb4b"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root, err := util.ComputeSigningRoot(test.objectRoot, test.domain)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.root, root)
+			}
+		})
+	}
+}
@@ -0,0 +1,533 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package slashingprotection provides slashing protection for validator signing,
+// including the EIP-3076 interchange format.
+package slashingprotection
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// InterchangeFormatVersion is the version of the interchange format generated by this package.
+	InterchangeFormatVersion = "5"
+	// legacyInterchangeFormatVersion is the version of the interchange format with complete and minimal variants.
+	legacyInterchangeFormatVersion = "4"
+)
+
+// Interchange is an EIP-3076 slashing protection interchange.
+type Interchange struct {
+	// GenesisValidatorsRoot is the genesis validators root of the chain to which the interchange applies.
+	GenesisValidatorsRoot []byte
+	// Records are the slashing protection records for each public key.
+	Records []*Record
+}
+
+// Record is the slashing protection record for a single public key.
+type Record struct {
+	PublicKey          []byte
+	SignedBlocks       []*SignedBlock
+	SignedAttestations []*SignedAttestation
+}
+
+// SignedBlock is a block signed by a public key.
+type SignedBlock struct {
+	Slot uint64
+	// SigningRoot is the signing root of the block; it is optional.
+	SigningRoot []byte
+}
+
+// SignedAttestation is an attestation signed by a public key.
+type SignedAttestation struct {
+	SourceEpoch uint64
+	TargetEpoch uint64
+	// SigningRoot is the signing root of the attestation; it is optional.
+	SigningRoot []byte
+}
+
+// Conflict is a slashable conflict found between slashing protection records.
+type Conflict struct {
+	PublicKey []byte
+	Reason    string
+}
+
+// ConflictError is returned when merging interchanges whose records conflict.
+type ConflictError struct {
+	Conflicts []*Conflict
+}
+
+// Error returns a description of the conflicts.
+func (e *ConflictError) Error() string {
+	reasons := make([]string, len(e.Conflicts))
+	for i := range e.Conflicts {
+		reasons[i] = fmt.Sprintf("%#x: %s", e.Conflicts[i].PublicKey, e.Conflicts[i].Reason)
+	}
+
+	return fmt.Sprintf("%d conflicts: %s", len(e.Conflicts), strings.Join(reasons, "; "))
+}
+
+type interchangeJSON struct {
+	Metadata *metadataJSON     `json:"metadata"`
+	Data     []json.RawMessage `json:"data"`
+}
+
+type metadataJSON struct {
+	InterchangeFormat        string `json:"interchange_format,omitempty"`
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+type recordJSON struct {
+	PublicKey          string                   `json:"pubkey"`
+	SignedBlocks       []*signedBlockJSON       `json:"signed_blocks"`
+	SignedAttestations []*signedAttestationJSON `json:"signed_attestations"`
+}
+
+type minimalRecordJSON struct {
+	PublicKey                        string `json:"pubkey"`
+	LastSignedBlockSlot              string `json:"last_signed_block_slot"`
+	LastSignedAttestationSourceEpoch string `json:"last_signed_attestation_source_epoch"`
+	LastSignedAttestationTargetEpoch string `json:"last_signed_attestation_target_epoch"`
+}
+
+type signedBlockJSON struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+type signedAttestationJSON struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// ParseInterchange parses and validates an interchange.
+// Version 5 interchanges are supported, as are the complete and minimal variants of version 4.
+// If genesisValidatorsRoot is supplied it must match that of the interchange.
+func ParseInterchange(data []byte, genesisValidatorsRoot []byte) (*Interchange, error) {
+	var interchange Interchange
+	if err := json.Unmarshal(data, &interchange); err != nil {
+		return nil, err
+	}
+	if genesisValidatorsRoot != nil && !bytes.Equal(genesisValidatorsRoot, interchange.GenesisValidatorsRoot) {
+		return nil, fmt.Errorf("interchange genesis validators root %#x does not match %#x",
+			interchange.GenesisValidatorsRoot, genesisValidatorsRoot)
+	}
+	if err := interchange.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &interchange, nil
+}
+
+// Validate validates the contents of the interchange.
+func (i *Interchange) Validate() error {
+	if len(i.GenesisValidatorsRoot) != 32 {
+		return errors.New("genesis validators root must be 32 bytes")
+	}
+	for j, record := range i.Records {
+		if record == nil {
+			return fmt.Errorf("record %d missing", j)
+		}
+		if len(record.PublicKey) != 48 {
+			return fmt.Errorf("record %d: public key must be 48 bytes", j)
+		}
+		for k, block := range record.SignedBlocks {
+			if block == nil {
+				return fmt.Errorf("record %d: signed block %d missing", j, k)
+			}
+			if block.SigningRoot != nil && len(block.SigningRoot) != 32 {
+				return fmt.Errorf("record %d: signed block %d: signing root must be 32 bytes", j, k)
+			}
+		}
+		for k, attestation := range record.SignedAttestations {
+			if attestation == nil {
+				return fmt.Errorf("record %d: signed attestation %d missing", j, k)
+			}
+			if attestation.SourceEpoch > attestation.TargetEpoch {
+				return fmt.Errorf("record %d: signed attestation %d: source epoch after target epoch", j, k)
+			}
+			if attestation.SigningRoot != nil && len(attestation.SigningRoot) != 32 {
+				return fmt.Errorf("record %d: signed attestation %d: signing root must be 32 bytes", j, k)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, generating a version 5 interchange.
+func (i *Interchange) MarshalJSON() ([]byte, error) {
+	data := make([]json.RawMessage, len(i.Records))
+	for j, record := range i.Records {
+		recordJSON := &recordJSON{
+			PublicKey:          fmt.Sprintf("%#x", record.PublicKey),
+			SignedBlocks:       make([]*signedBlockJSON, len(record.SignedBlocks)),
+			SignedAttestations: make([]*signedAttestationJSON, len(record.SignedAttestations)),
+		}
+		for k, block := range record.SignedBlocks {
+			recordJSON.SignedBlocks[k] = &signedBlockJSON{
+				Slot:        strconv.FormatUint(block.Slot, 10),
+				SigningRoot: optionalHex(block.SigningRoot),
+			}
+		}
+		for k, attestation := range record.SignedAttestations {
+			recordJSON.SignedAttestations[k] = &signedAttestationJSON{
+				SourceEpoch: strconv.FormatUint(attestation.SourceEpoch, 10),
+				TargetEpoch: strconv.FormatUint(attestation.TargetEpoch, 10),
+				SigningRoot: optionalHex(attestation.SigningRoot),
+			}
+		}
+		var err error
+		data[j], err = json.Marshal(recordJSON)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(&interchangeJSON{
+		Metadata: &metadataJSON{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", i.GenesisValidatorsRoot),
+		},
+		Data: data,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Interchange) UnmarshalJSON(input []byte) error {
+	var data interchangeJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+	if data.Metadata == nil {
+		return errors.New("metadata missing")
+	}
+	minimal := false
+	switch data.Metadata.InterchangeFormatVersion {
+	case InterchangeFormatVersion:
+	case legacyInterchangeFormatVersion:
+		switch data.Metadata.InterchangeFormat {
+		case "complete":
+		case "minimal":
+			minimal = true
+		default:
+			return fmt.Errorf("unsupported interchange format %q", data.Metadata.InterchangeFormat)
+		}
+	default:
+		return fmt.Errorf("unsupported interchange format version %q", data.Metadata.InterchangeFormatVersion)
+	}
+	var err error
+	i.GenesisValidatorsRoot, err = parseHex(data.Metadata.GenesisValidatorsRoot)
+	if err != nil {
+		return errors.Wrap(err, "invalid genesis validators root")
+	}
+
+	i.Records = make([]*Record, len(data.Data))
+	for j := range data.Data {
+		if minimal {
+			i.Records[j], err = parseMinimalRecord(data.Data[j])
+		} else {
+			i.Records[j], err = parseRecord(data.Data[j])
+		}
+		if err != nil {
+			return errors.Wrapf(err, "record %d", j)
+		}
+	}
+
+	return nil
+}
+
+// Minimal returns an interchange holding only the highest signed block and attestation epochs for each public key,
+// which is sufficient to prevent slashable signing.
+func (i *Interchange) Minimal() *Interchange {
+	res := &Interchange{
+		GenesisValidatorsRoot: i.GenesisValidatorsRoot,
+		Records:               make([]*Record, 0, len(i.Records)),
+	}
+	for _, record := range i.Records {
+		minimal := &Record{PublicKey: record.PublicKey}
+		for _, block := range record.SignedBlocks {
+			if len(minimal.SignedBlocks) == 0 || block.Slot > minimal.SignedBlocks[0].Slot {
+				minimal.SignedBlocks = []*SignedBlock{{Slot: block.Slot}}
+			}
+		}
+		var maxSource, maxTarget uint64
+		for _, attestation := range record.SignedAttestations {
+			if attestation.SourceEpoch > maxSource {
+				maxSource = attestation.SourceEpoch
+			}
+			if attestation.TargetEpoch > maxTarget {
+				maxTarget = attestation.TargetEpoch
+			}
+		}
+		if len(record.SignedAttestations) > 0 {
+			minimal.SignedAttestations = []*SignedAttestation{{SourceEpoch: maxSource, TargetEpoch: maxTarget}}
+		}
+		res.Records = append(res.Records, minimal)
+	}
+
+	return res
+}
+
+// Merge merges interchanges for the same chain.
+// Duplicate entries are combined; if any entries are slashable with respect to each other a *ConflictError is returned.
+func Merge(interchanges ...*Interchange) (*Interchange, error) {
+	if len(interchanges) == 0 {
+		return nil, errors.New("no interchanges supplied")
+	}
+
+	res := &Interchange{
+		GenesisValidatorsRoot: interchanges[0].GenesisValidatorsRoot,
+	}
+	records := make(map[string]*Record)
+	for i, interchange := range interchanges {
+		if !bytes.Equal(interchange.GenesisValidatorsRoot, res.GenesisValidatorsRoot) {
+			return nil, fmt.Errorf("interchange %d is for a different genesis validators root", i)
+		}
+		for _, record := range interchange.Records {
+			key := string(record.PublicKey)
+			merged, exists := records[key]
+			if !exists {
+				merged = &Record{PublicKey: record.PublicKey}
+				records[key] = merged
+				res.Records = append(res.Records, merged)
+			}
+			merged.SignedBlocks = append(merged.SignedBlocks, record.SignedBlocks...)
+			merged.SignedAttestations = append(merged.SignedAttestations, record.SignedAttestations...)
+		}
+	}
+
+	conflicts := make([]*Conflict, 0)
+	for _, record := range res.Records {
+		var reasons []string
+		record.SignedBlocks, reasons = mergeBlocks(record.SignedBlocks)
+		for _, reason := range reasons {
+			conflicts = append(conflicts, &Conflict{PublicKey: record.PublicKey, Reason: reason})
+		}
+		record.SignedAttestations, reasons = mergeAttestations(record.SignedAttestations)
+		for _, reason := range reasons {
+			conflicts = append(conflicts, &Conflict{PublicKey: record.PublicKey, Reason: reason})
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &ConflictError{Conflicts: conflicts}
+	}
+
+	return res, nil
+}
+
+// mergeBlocks sorts and deduplicates signed blocks, returning descriptions of any double proposals.
+func mergeBlocks(blocks []*SignedBlock) ([]*SignedBlock, []string) {
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return blocks[i].Slot < blocks[j].Slot
+	})
+
+	res := make([]*SignedBlock, 0, len(blocks))
+	var conflicts []string
+	for _, block := range blocks {
+		if len(res) == 0 || res[len(res)-1].Slot != block.Slot {
+			res = append(res, block)
+
+			continue
+		}
+		last := res[len(res)-1]
+		switch {
+		case last.SigningRoot == nil:
+			res[len(res)-1] = block
+		case block.SigningRoot == nil, bytes.Equal(last.SigningRoot, block.SigningRoot):
+		default:
+			conflicts = append(conflicts, fmt.Sprintf("double proposal at slot %d", block.Slot))
+		}
+	}
+
+	return res, conflicts
+}
+
+// mergeAttestations sorts and deduplicates signed attestations, returning descriptions of any double or surround votes.
+func mergeAttestations(attestations []*SignedAttestation) ([]*SignedAttestation, []string) {
+	if len(attestations) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(attestations, func(i, j int) bool {
+		if attestations[i].TargetEpoch != attestations[j].TargetEpoch {
+			return attestations[i].TargetEpoch < attestations[j].TargetEpoch
+		}
+
+		return attestations[i].SourceEpoch < attestations[j].SourceEpoch
+	})
+
+	res := make([]*SignedAttestation, 0, len(attestations))
+	var conflicts []string
+	for _, attestation := range attestations {
+		duplicate := false
+		for i, existing := range res {
+			if existing.TargetEpoch != attestation.TargetEpoch {
+				continue
+			}
+			if existing.SourceEpoch == attestation.SourceEpoch {
+				if existing.SigningRoot == nil {
+					res[i] = attestation
+					duplicate = true
+
+					break
+				}
+				if attestation.SigningRoot == nil || bytes.Equal(existing.SigningRoot, attestation.SigningRoot) {
+					duplicate = true
+
+					break
+				}
+			}
+			conflicts = append(conflicts, fmt.Sprintf("double vote at target epoch %d", attestation.TargetEpoch))
+			duplicate = true
+
+			break
+		}
+		if duplicate {
+			continue
+		}
+		for _, existing := range res {
+			if surrounds(existing, attestation) || surrounds(attestation, existing) {
+				conflicts = append(conflicts, fmt.Sprintf("surround vote between %d->%d and %d->%d",
+					existing.SourceEpoch, existing.TargetEpoch, attestation.SourceEpoch, attestation.TargetEpoch))
+			}
+		}
+		res = append(res, attestation)
+	}
+
+	return res, conflicts
+}
+
+// surrounds returns true if the first attestation surrounds the second.
+func surrounds(a *SignedAttestation, b *SignedAttestation) bool {
+	return a.SourceEpoch < b.SourceEpoch && a.TargetEpoch > b.TargetEpoch
+}
+
+func parseRecord(input []byte) (*Record, error) {
+	var data recordJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON")
+	}
+	var err error
+	record := &Record{
+		SignedBlocks:       make([]*SignedBlock, len(data.SignedBlocks)),
+		SignedAttestations: make([]*SignedAttestation, len(data.SignedAttestations)),
+	}
+	record.PublicKey, err = parseHex(data.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	for i, block := range data.SignedBlocks {
+		if block == nil {
+			return nil, fmt.Errorf("signed block %d missing", i)
+		}
+		record.SignedBlocks[i] = &SignedBlock{}
+		record.SignedBlocks[i].Slot, err = strconv.ParseUint(block.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "signed block %d: invalid slot", i)
+		}
+		record.SignedBlocks[i].SigningRoot, err = parseOptionalHex(block.SigningRoot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "signed block %d: invalid signing root", i)
+		}
+	}
+	for i, attestation := range data.SignedAttestations {
+		if attestation == nil {
+			return nil, fmt.Errorf("signed attestation %d missing", i)
+		}
+		record.SignedAttestations[i] = &SignedAttestation{}
+		record.SignedAttestations[i].SourceEpoch, err = strconv.ParseUint(attestation.SourceEpoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "signed attestation %d: invalid source epoch", i)
+		}
+		record.SignedAttestations[i].TargetEpoch, err = strconv.ParseUint(attestation.TargetEpoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "signed attestation %d: invalid target epoch", i)
+		}
+		record.SignedAttestations[i].SigningRoot, err = parseOptionalHex(attestation.SigningRoot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "signed attestation %d: invalid signing root", i)
+		}
+	}
+
+	return record, nil
+}
+
+func parseMinimalRecord(input []byte) (*Record, error) {
+	var data minimalRecordJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON")
+	}
+	var err error
+	record := &Record{}
+	record.PublicKey, err = parseHex(data.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	if data.LastSignedBlockSlot != "" {
+		slot, err := strconv.ParseUint(data.LastSignedBlockSlot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid last signed block slot")
+		}
+		record.SignedBlocks = []*SignedBlock{{Slot: slot}}
+	}
+	if data.LastSignedAttestationSourceEpoch != "" || data.LastSignedAttestationTargetEpoch != "" {
+		sourceEpoch, err := strconv.ParseUint(data.LastSignedAttestationSourceEpoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid last signed attestation source epoch")
+		}
+		targetEpoch, err := strconv.ParseUint(data.LastSignedAttestationTargetEpoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid last signed attestation target epoch")
+		}
+		record.SignedAttestations = []*SignedAttestation{{SourceEpoch: sourceEpoch, TargetEpoch: targetEpoch}}
+	}
+
+	return record, nil
+}
+
+func parseHex(input string) ([]byte, error) {
+	if !strings.HasPrefix(input, "0x") {
+		return nil, errors.New("missing 0x prefix")
+	}
+
+	return hex.DecodeString(input[2:])
+}
+
+func parseOptionalHex(input string) ([]byte, error) {
+	if input == "" {
+		return nil, nil
+	}
+
+	return parseHex(input)
+}
+
+func optionalHex(data []byte) string {
+	if data == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%#x", data)
+}
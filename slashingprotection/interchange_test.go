@@ -0,0 +1,368 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotection_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/go-eth2-util/slashingprotection"
+)
+
+func _byteArray(input string) []byte {
+	res, _ := hex.DecodeString(input)
+	return res
+}
+
+const (
+	testPubKey  = "b845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed"
+	testPubKey2 = "a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	testGVR     = "04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	testRoot1   = "4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"
+	testRoot2   = "587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d"
+)
+
+func TestParseInterchange(t *testing.T) {
+	tests := []struct {
+		name                  string
+		input                 string
+		genesisValidatorsRoot []byte
+		err                   string
+		interchange           *slashingprotection.Interchange
+	}{
+		{
+			name:  "Empty",
+			input: ``,
+			err:   "unexpected end of JSON input",
+		},
+		{
+			name:  "MetadataMissing",
+			input: `{"data":[]}`,
+			err:   "metadata missing",
+		},
+		{
+			name:  "VersionUnsupported",
+			input: `{"metadata":{"interchange_format_version":"3","genesis_validators_root":"0x` + testGVR + `"},"data":[]}`,
+			err:   `unsupported interchange format version "3"`,
+		},
+		{
+			name:  "LegacyFormatUnsupported",
+			input: `{"metadata":{"interchange_format":"partial","interchange_format_version":"4","genesis_validators_root":"0x` + testGVR + `"},"data":[]}`,
+			err:   `unsupported interchange format "partial"`,
+		},
+		{
+			name:  "GenesisValidatorsRootInvalid",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + testGVR + `"},"data":[]}`,
+			err:   "invalid genesis validators root: missing 0x prefix",
+		},
+		{
+			name:  "GenesisValidatorsRootShort",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x0102"},"data":[]}`,
+			err:   "genesis validators root must be 32 bytes",
+		},
+		{
+			name:                  "GenesisValidatorsRootMismatch",
+			input:                 `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x` + testGVR + `"},"data":[]}`,
+			genesisValidatorsRoot: make([]byte, 32),
+			err:                   "interchange genesis validators root 0x" + testGVR + " does not match 0x0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:  "PublicKeyShort",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x` + testGVR + `"},"data":[{"pubkey":"0x0102","signed_blocks":[],"signed_attestations":[]}]}`,
+			err:   "record 0: public key must be 48 bytes",
+		},
+		{
+			name:  "SlotInvalid",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x` + testGVR + `"},"data":[{"pubkey":"0x` + testPubKey + `","signed_blocks":[{"slot":"-1"}],"signed_attestations":[]}]}`,
+			err:   `record 0: signed block 0: invalid slot: strconv.ParseUint: parsing "-1": invalid syntax`,
+		},
+		{
+			name:  "SourceAfterTarget",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x` + testGVR + `"},"data":[{"pubkey":"0x` + testPubKey + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"3","target_epoch":"2"}]}]}`,
+			err:   "record 0: signed attestation 0: source epoch after target epoch",
+		},
+		{
+			name:  "SigningRootShort",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x` + testGVR + `"},"data":[{"pubkey":"0x` + testPubKey + `","signed_blocks":[{"slot":"1","signing_root":"0x01"}],"signed_attestations":[]}]}`,
+			err:   "record 0: signed block 0: signing root must be 32 bytes",
+		},
+		{
+			name: "Good",
+			input: `{
+  "metadata": {
+    "interchange_format_version": "5",
+    "genesis_validators_root": "0x` + testGVR + `"
+  },
+  "data": [
+    {
+      "pubkey": "0x` + testPubKey + `",
+      "signed_blocks": [
+        {"slot": "81952", "signing_root": "0x` + testRoot1 + `"},
+        {"slot": "81951"}
+      ],
+      "signed_attestations": [
+        {"source_epoch": "2290", "target_epoch": "3007", "signing_root": "0x` + testRoot2 + `"},
+        {"source_epoch": "2290", "target_epoch": "3008"}
+      ]
+    }
+  ]
+}`,
+			genesisValidatorsRoot: _byteArray(testGVR),
+			interchange: &slashingprotection.Interchange{
+				GenesisValidatorsRoot: _byteArray(testGVR),
+				Records: []*slashingprotection.Record{
+					{
+						PublicKey: _byteArray(testPubKey),
+						SignedBlocks: []*slashingprotection.SignedBlock{
+							{Slot: 81952, SigningRoot: _byteArray(testRoot1)},
+							{Slot: 81951},
+						},
+						SignedAttestations: []*slashingprotection.SignedAttestation{
+							{SourceEpoch: 2290, TargetEpoch: 3007, SigningRoot: _byteArray(testRoot2)},
+							{SourceEpoch: 2290, TargetEpoch: 3008},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "LegacyComplete",
+			input: `{"metadata":{"interchange_format":"complete","interchange_format_version":"4","genesis_validators_root":"0x` + testGVR + `"},` +
+				`"data":[{"pubkey":"0x` + testPubKey + `","signed_blocks":[{"slot":"5"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]}]}`,
+			interchange: &slashingprotection.Interchange{
+				GenesisValidatorsRoot: _byteArray(testGVR),
+				Records: []*slashingprotection.Record{
+					{
+						PublicKey:          _byteArray(testPubKey),
+						SignedBlocks:       []*slashingprotection.SignedBlock{{Slot: 5}},
+						SignedAttestations: []*slashingprotection.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 2}},
+					},
+				},
+			},
+		},
+		{
+			name: "LegacyMinimal",
+			input: `{"metadata":{"interchange_format":"minimal","interchange_format_version":"4","genesis_validators_root":"0x` + testGVR + `"},` +
+				`"data":[{"pubkey":"0x` + testPubKey + `","last_signed_block_slot":"5","last_signed_attestation_source_epoch":"1","last_signed_attestation_target_epoch":"2"},` +
+				`{"pubkey":"0x` + testPubKey2 + `"}]}`,
+			interchange: &slashingprotection.Interchange{
+				GenesisValidatorsRoot: _byteArray(testGVR),
+				Records: []*slashingprotection.Record{
+					{
+						PublicKey:          _byteArray(testPubKey),
+						SignedBlocks:       []*slashingprotection.SignedBlock{{Slot: 5}},
+						SignedAttestations: []*slashingprotection.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 2}},
+					},
+					{
+						PublicKey: _byteArray(testPubKey2),
+					},
+				},
+			},
+		},
+		{
+			name: "LegacyMinimalBadEpoch",
+			input: `{"metadata":{"interchange_format":"minimal","interchange_format_version":"4","genesis_validators_root":"0x` + testGVR + `"},` +
+				`"data":[{"pubkey":"0x` + testPubKey + `","last_signed_attestation_source_epoch":"1"}]}`,
+			err: `record 0: invalid last signed attestation target epoch: strconv.ParseUint: parsing "": invalid syntax`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			interchange, err := slashingprotection.ParseInterchange([]byte(test.input), test.genesisValidatorsRoot)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.interchange, interchange)
+			}
+		})
+	}
+}
+
+func TestInterchangeRoundTrip(t *testing.T) {
+	interchange := &slashingprotection.Interchange{
+		GenesisValidatorsRoot: _byteArray(testGVR),
+		Records: []*slashingprotection.Record{
+			{
+				PublicKey:          _byteArray(testPubKey),
+				SignedBlocks:       []*slashingprotection.SignedBlock{{Slot: 5, SigningRoot: _byteArray(testRoot1)}},
+				SignedAttestations: []*slashingprotection.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 2}},
+			},
+		},
+	}
+
+	data, err := json.Marshal(interchange)
+	require.NoError(t, err)
+	assert.Equal(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x`+testGVR+`"},`+
+		`"data":[{"pubkey":"0x`+testPubKey+`","signed_blocks":[{"slot":"5","signing_root":"0x`+testRoot1+`"}],`+
+		`"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]}]}`, string(data))
+
+	parsed, err := slashingprotection.ParseInterchange(data, nil)
+	require.NoError(t, err)
+	assert.Equal(t, interchange, parsed)
+}
+
+func TestInterchangeMinimal(t *testing.T) {
+	interchange := &slashingprotection.Interchange{
+		GenesisValidatorsRoot: _byteArray(testGVR),
+		Records: []*slashingprotection.Record{
+			{
+				PublicKey: _byteArray(testPubKey),
+				SignedBlocks: []*slashingprotection.SignedBlock{
+					{Slot: 7, SigningRoot: _byteArray(testRoot1)},
+					{Slot: 9},
+					{Slot: 8},
+				},
+				SignedAttestations: []*slashingprotection.SignedAttestation{
+					{SourceEpoch: 4, TargetEpoch: 5},
+					{SourceEpoch: 5, TargetEpoch: 6, SigningRoot: _byteArray(testRoot2)},
+				},
+			},
+			{
+				PublicKey: _byteArray(testPubKey2),
+			},
+		},
+	}
+
+	assert.Equal(t, &slashingprotection.Interchange{
+		GenesisValidatorsRoot: _byteArray(testGVR),
+		Records: []*slashingprotection.Record{
+			{
+				PublicKey:          _byteArray(testPubKey),
+				SignedBlocks:       []*slashingprotection.SignedBlock{{Slot: 9}},
+				SignedAttestations: []*slashingprotection.SignedAttestation{{SourceEpoch: 5, TargetEpoch: 6}},
+			},
+			{
+				PublicKey: _byteArray(testPubKey2),
+			},
+		},
+	}, interchange.Minimal())
+}
+
+func TestMerge(t *testing.T) {
+	record := func(blocks []*slashingprotection.SignedBlock, attestations []*slashingprotection.SignedAttestation) *slashingprotection.Interchange {
+		return &slashingprotection.Interchange{
+			GenesisValidatorsRoot: _byteArray(testGVR),
+			Records: []*slashingprotection.Record{
+				{
+					PublicKey:          _byteArray(testPubKey),
+					SignedBlocks:       blocks,
+					SignedAttestations: attestations,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		interchanges []*slashingprotection.Interchange
+		err          string
+		conflicts    int
+		merged       *slashingprotection.Interchange
+	}{
+		{
+			name: "Empty",
+			err:  "no interchanges supplied",
+		},
+		{
+			name: "GenesisValidatorsRootMismatch",
+			interchanges: []*slashingprotection.Interchange{
+				record(nil, nil),
+				{GenesisValidatorsRoot: make([]byte, 32)},
+			},
+			err: "interchange 1 is for a different genesis validators root",
+		},
+		{
+			name: "Duplicates",
+			interchanges: []*slashingprotection.Interchange{
+				record([]*slashingprotection.SignedBlock{{Slot: 2}, {Slot: 1}},
+					[]*slashingprotection.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 2}}),
+				record([]*slashingprotection.SignedBlock{{Slot: 2, SigningRoot: _byteArray(testRoot1)}},
+					[]*slashingprotection.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 2, SigningRoot: _byteArray(testRoot2)}, {SourceEpoch: 2, TargetEpoch: 3}}),
+				{
+					GenesisValidatorsRoot: _byteArray(testGVR),
+					Records:               []*slashingprotection.Record{{PublicKey: _byteArray(testPubKey2)}},
+				},
+			},
+			merged: &slashingprotection.Interchange{
+				GenesisValidatorsRoot: _byteArray(testGVR),
+				Records: []*slashingprotection.Record{
+					{
+						PublicKey: _byteArray(testPubKey),
+						SignedBlocks: []*slashingprotection.SignedBlock{
+							{Slot: 1},
+							{Slot: 2, SigningRoot: _byteArray(testRoot1)},
+						},
+						SignedAttestations: []*slashingprotection.SignedAttestation{
+							{SourceEpoch: 1, TargetEpoch: 2, SigningRoot: _byteArray(testRoot2)},
+							{SourceEpoch: 2, TargetEpoch: 3},
+						},
+					},
+					{
+						PublicKey: _byteArray(testPubKey2),
+					},
+				},
+			},
+		},
+		{
+			name: "DoubleProposal",
+			interchanges: []*slashingprotection.Interchange{
+				record([]*slashingprotection.SignedBlock{{Slot: 2, SigningRoot: _byteArray(testRoot1)}}, nil),
+				record([]*slashingprotection.SignedBlock{{Slot: 2, SigningRoot: _byteArray(testRoot2)}}, nil),
+			},
+			err:       "1 conflicts: 0x" + testPubKey + ": double proposal at slot 2",
+			conflicts: 1,
+		},
+		{
+			name: "DoubleVote",
+			interchanges: []*slashingprotection.Interchange{
+				record(nil, []*slashingprotection.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 3}}),
+				record(nil, []*slashingprotection.SignedAttestation{{SourceEpoch: 2, TargetEpoch: 3}}),
+			},
+			err:       "1 conflicts: 0x" + testPubKey + ": double vote at target epoch 3",
+			conflicts: 1,
+		},
+		{
+			name: "SurroundVote",
+			interchanges: []*slashingprotection.Interchange{
+				record(nil, []*slashingprotection.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 5}}),
+				record(nil, []*slashingprotection.SignedAttestation{{SourceEpoch: 2, TargetEpoch: 3}}),
+			},
+			err:       "1 conflicts: 0x" + testPubKey + ": surround vote between 2->3 and 1->5",
+			conflicts: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, err := slashingprotection.Merge(test.interchanges...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				if test.conflicts > 0 {
+					var conflictErr *slashingprotection.ConflictError
+					require.True(t, errors.As(err, &conflictErr))
+					assert.Len(t, conflictErr.Conflicts, test.conflicts)
+				}
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.merged, merged)
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotection
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrSlashable is returned when signing a block or attestation could result in slashing.
+var ErrSlashable = errors.New("slashable")
+
+// Store is a file-backed slashing protection store.
+// The file holds a version 5 interchange, so can be read by any client that supports EIP-3076.
+// Signing refuses anything at or before the latest signed block or attestation, so once a key signs only its
+// latest block and attestation are kept, which stops the file growing with every signature.
+type Store struct {
+	path        string
+	mutex       sync.Mutex
+	interchange *Interchange
+	records     map[string]*Record
+}
+
+// NewStore opens the slashing protection store at the given path for the chain with the given genesis
+// validators root, creating it if it does not exist.
+func NewStore(path string, genesisValidatorsRoot []byte) (*Store, error) {
+	if len(genesisValidatorsRoot) != 32 {
+		return nil, errors.New("genesis validators root must be 32 bytes")
+	}
+
+	interchange := &Interchange{
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		interchange, err = ParseInterchange(data, genesisValidatorsRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse slashing protection store")
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, errors.Wrap(err, "failed to read slashing protection store")
+	}
+
+	// Merging a single interchange deduplicates and sorts its entries, and rejects it if it is already slashable.
+	interchange, err = Merge(interchange)
+	if err != nil {
+		return nil, errors.Wrap(err, "slashing protection store is inconsistent")
+	}
+
+	s := &Store{
+		path: path,
+	}
+	s.setInterchange(interchange)
+
+	return s, nil
+}
+
+// CheckAndRecordBlock checks that signing a block for the public key at the given slot is not slashable,
+// and records it if so.
+// Signing a block identical to one already signed is allowed.
+func (s *Store) CheckAndRecordBlock(pubKey []byte, slot uint64, signingRoot []byte) error {
+	if len(pubKey) != 48 {
+		return errors.New("public key must be 48 bytes")
+	}
+	if len(signingRoot) != 32 {
+		return errors.New("signing root must be 32 bytes")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record := s.record(pubKey)
+	for _, block := range record.SignedBlocks {
+		if block.Slot == slot && bytes.Equal(block.SigningRoot, signingRoot) {
+			return nil
+		}
+	}
+	for _, block := range record.SignedBlocks {
+		if block.Slot == slot {
+			return fmt.Errorf("%w: double proposal at slot %d", ErrSlashable, slot)
+		}
+		// Imported history can be incomplete, so refuse to sign anything at or before the latest block.
+		if block.Slot > slot {
+			return fmt.Errorf("%w: slot %d is before signed block at slot %d", ErrSlashable, slot, block.Slot)
+		}
+	}
+
+	// The new block is after all signed blocks, so is the only one required to check later blocks.
+	previous := record.SignedBlocks
+	record.SignedBlocks = []*SignedBlock{{
+		Slot:        slot,
+		SigningRoot: append([]byte{}, signingRoot...),
+	}}
+	if err := s.save(); err != nil {
+		record.SignedBlocks = previous
+
+		return err
+	}
+
+	return nil
+}
+
+// CheckAndRecordAttestation checks that signing an attestation for the public key with the given source and
+// target epochs is not slashable, and records it if so.
+// Signing an attestation identical to one already signed is allowed.
+func (s *Store) CheckAndRecordAttestation(pubKey []byte, sourceEpoch uint64, targetEpoch uint64, signingRoot []byte) error {
+	if len(pubKey) != 48 {
+		return errors.New("public key must be 48 bytes")
+	}
+	if len(signingRoot) != 32 {
+		return errors.New("signing root must be 32 bytes")
+	}
+	if sourceEpoch > targetEpoch {
+		return errors.New("source epoch after target epoch")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record := s.record(pubKey)
+	for _, attestation := range record.SignedAttestations {
+		if attestation.SourceEpoch == sourceEpoch &&
+			attestation.TargetEpoch == targetEpoch &&
+			bytes.Equal(attestation.SigningRoot, signingRoot) {
+			return nil
+		}
+	}
+	if err := checkAttestation(record.SignedAttestations, sourceEpoch, targetEpoch); err != nil {
+		return err
+	}
+
+	// The new attestation has the highest source and target epochs signed, so is the only one required to check
+	// later attestations.
+	previous := record.SignedAttestations
+	record.SignedAttestations = []*SignedAttestation{{
+		SourceEpoch: sourceEpoch,
+		TargetEpoch: targetEpoch,
+		SigningRoot: append([]byte{}, signingRoot...),
+	}}
+	if err := s.save(); err != nil {
+		record.SignedAttestations = previous
+
+		return err
+	}
+
+	return nil
+}
+
+// checkAttestation checks an attestation against those already signed.
+func checkAttestation(attestations []*SignedAttestation, sourceEpoch uint64, targetEpoch uint64) error {
+	for _, attestation := range attestations {
+		switch {
+		case attestation.TargetEpoch == targetEpoch:
+			return fmt.Errorf("%w: double vote at target epoch %d", ErrSlashable, targetEpoch)
+		case attestation.SourceEpoch < sourceEpoch && targetEpoch < attestation.TargetEpoch:
+			return fmt.Errorf("%w: %d->%d is surrounded by %d->%d",
+				ErrSlashable, sourceEpoch, targetEpoch, attestation.SourceEpoch, attestation.TargetEpoch)
+		case sourceEpoch < attestation.SourceEpoch && attestation.TargetEpoch < targetEpoch:
+			return fmt.Errorf("%w: %d->%d surrounds %d->%d",
+				ErrSlashable, sourceEpoch, targetEpoch, attestation.SourceEpoch, attestation.TargetEpoch)
+		}
+	}
+	// Imported history can be incomplete, so refuse to sign anything before the latest attestation.
+	for _, attestation := range attestations {
+		if sourceEpoch < attestation.SourceEpoch {
+			return fmt.Errorf("%w: source epoch %d is before signed source epoch %d",
+				ErrSlashable, sourceEpoch, attestation.SourceEpoch)
+		}
+		if targetEpoch < attestation.TargetEpoch {
+			return fmt.Errorf("%w: target epoch %d is before signed target epoch %d",
+				ErrSlashable, targetEpoch, attestation.TargetEpoch)
+		}
+	}
+
+	return nil
+}
+
+// Import merges an interchange in to the store.
+// The import is rejected in full if the interchange conflicts with the contents of the store.
+func (s *Store) Import(interchange *Interchange) error {
+	if interchange == nil {
+		return errors.New("no interchange supplied")
+	}
+	if err := interchange.Validate(); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !bytes.Equal(interchange.GenesisValidatorsRoot, s.interchange.GenesisValidatorsRoot) {
+		return fmt.Errorf("interchange genesis validators root %#x does not match %#x",
+			interchange.GenesisValidatorsRoot, s.interchange.GenesisValidatorsRoot)
+	}
+	merged, err := Merge(s.interchange, interchange)
+	if err != nil {
+		return err
+	}
+
+	previous := s.interchange
+	s.setInterchange(merged)
+	if err := s.save(); err != nil {
+		s.setInterchange(previous)
+
+		return err
+	}
+
+	return nil
+}
+
+// Export exports the contents of the store as an interchange.
+// If public keys are supplied only their records are exported.
+func (s *Store) Export(pubKeys ...[]byte) *Interchange {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	res := &Interchange{
+		GenesisValidatorsRoot: s.interchange.GenesisValidatorsRoot,
+	}
+	if len(pubKeys) == 0 {
+		for _, record := range s.interchange.Records {
+			res.Records = append(res.Records, copyRecord(record))
+		}
+
+		return res
+	}
+	for _, pubKey := range pubKeys {
+		if record, exists := s.records[string(pubKey)]; exists {
+			res.Records = append(res.Records, copyRecord(record))
+		}
+	}
+
+	return res
+}
+
+// record returns the record for a public key, creating it if required.
+func (s *Store) record(pubKey []byte) *Record {
+	record, exists := s.records[string(pubKey)]
+	if !exists {
+		record = &Record{
+			PublicKey: append([]byte{}, pubKey...),
+		}
+		s.records[string(pubKey)] = record
+		s.interchange.Records = append(s.interchange.Records, record)
+	}
+
+	return record
+}
+
+// setInterchange sets the contents of the store.
+func (s *Store) setInterchange(interchange *Interchange) {
+	s.interchange = interchange
+	s.records = make(map[string]*Record, len(interchange.Records))
+	for _, record := range interchange.Records {
+		s.records[string(record.PublicKey)] = record
+	}
+}
+
+// save writes the store to its file.
+// The contents are written to a temporary file that replaces the existing file, so that the
+// store is not left partially written.
+func (s *Store) save() error {
+	data, err := s.interchange.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal slashing protection store")
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create slashing protection store")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return errors.Wrap(err, "failed to write slashing protection store")
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+
+		return errors.Wrap(err, "failed to sync slashing protection store")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to close slashing protection store")
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return errors.Wrap(err, "failed to replace slashing protection store")
+	}
+
+	return nil
+}
+
+// copyRecord returns a copy of a record that is safe to use outside of the store.
+func copyRecord(record *Record) *Record {
+	res := &Record{
+		PublicKey: record.PublicKey,
+	}
+	for _, block := range record.SignedBlocks {
+		res.SignedBlocks = append(res.SignedBlocks, &SignedBlock{Slot: block.Slot, SigningRoot: block.SigningRoot})
+	}
+	for _, attestation := range record.SignedAttestations {
+		res.SignedAttestations = append(res.SignedAttestations, &SignedAttestation{
+			SourceEpoch: attestation.SourceEpoch,
+			TargetEpoch: attestation.TargetEpoch,
+			SigningRoot: attestation.SigningRoot,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,288 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotection_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/go-eth2-util/slashingprotection"
+)
+
+func TestNewStore(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := slashingprotection.NewStore(filepath.Join(dir, "short.json"), []byte{0x01})
+	require.EqualError(t, err, "genesis validators root must be 32 bytes")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.json"), []byte("bad"), 0o600))
+	_, err = slashingprotection.NewStore(filepath.Join(dir, "bad.json"), _byteArray(testGVR))
+	require.ErrorContains(t, err, "failed to parse slashing protection store: invalid character")
+
+	other, err := slashingprotection.NewStore(filepath.Join(dir, "other.json"), make([]byte, 32))
+	require.NoError(t, err)
+	require.NoError(t, other.CheckAndRecordBlock(_byteArray(testPubKey), 1, _byteArray(testRoot1)))
+	_, err = slashingprotection.NewStore(filepath.Join(dir, "other.json"), _byteArray(testGVR))
+	require.ErrorContains(t, err, "does not match")
+
+	inconsistent := `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x` + testGVR + `"},` +
+		`"data":[{"pubkey":"0x` + testPubKey + `","signed_blocks":[{"slot":"1","signing_root":"0x` + testRoot1 + `"},` +
+		`{"slot":"1","signing_root":"0x` + testRoot2 + `"}],"signed_attestations":[]}]}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "inconsistent.json"), []byte(inconsistent), 0o600))
+	_, err = slashingprotection.NewStore(filepath.Join(dir, "inconsistent.json"), _byteArray(testGVR))
+	require.EqualError(t, err, "slashing protection store is inconsistent: 1 conflicts: 0x"+testPubKey+": double proposal at slot 1")
+}
+
+func TestCheckAndRecordBlock(t *testing.T) {
+	store, err := slashingprotection.NewStore(filepath.Join(t.TempDir(), "store.json"), _byteArray(testGVR))
+	require.NoError(t, err)
+
+	require.EqualError(t, store.CheckAndRecordBlock([]byte{0x01}, 1, _byteArray(testRoot1)), "public key must be 48 bytes")
+	require.EqualError(t, store.CheckAndRecordBlock(_byteArray(testPubKey), 1, nil), "signing root must be 32 bytes")
+
+	tests := []struct {
+		name        string
+		slot        uint64
+		signingRoot []byte
+		err         string
+	}{
+		{
+			name:        "First",
+			slot:        10,
+			signingRoot: _byteArray(testRoot1),
+		},
+		{
+			name:        "Repeat",
+			slot:        10,
+			signingRoot: _byteArray(testRoot1),
+		},
+		{
+			name:        "DoubleProposal",
+			slot:        10,
+			signingRoot: _byteArray(testRoot2),
+			err:         "slashable: double proposal at slot 10",
+		},
+		{
+			name:        "Earlier",
+			slot:        9,
+			signingRoot: _byteArray(testRoot2),
+			err:         "slashable: slot 9 is before signed block at slot 10",
+		},
+		{
+			name:        "Later",
+			slot:        11,
+			signingRoot: _byteArray(testRoot2),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := store.CheckAndRecordBlock(_byteArray(testPubKey), test.slot, test.signingRoot)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				assert.True(t, errors.Is(err, slashingprotection.ErrSlashable))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckAndRecordAttestation(t *testing.T) {
+	store, err := slashingprotection.NewStore(filepath.Join(t.TempDir(), "store.json"), _byteArray(testGVR))
+	require.NoError(t, err)
+
+	require.EqualError(t, store.CheckAndRecordAttestation(_byteArray(testPubKey), 2, 1, _byteArray(testRoot1)),
+		"source epoch after target epoch")
+
+	tests := []struct {
+		name        string
+		sourceEpoch uint64
+		targetEpoch uint64
+		signingRoot []byte
+		err         string
+	}{
+		{
+			name:        "First",
+			sourceEpoch: 5,
+			targetEpoch: 10,
+			signingRoot: _byteArray(testRoot1),
+		},
+		{
+			name:        "Repeat",
+			sourceEpoch: 5,
+			targetEpoch: 10,
+			signingRoot: _byteArray(testRoot1),
+		},
+		{
+			name:        "DoubleVote",
+			sourceEpoch: 5,
+			targetEpoch: 10,
+			signingRoot: _byteArray(testRoot2),
+			err:         "slashable: double vote at target epoch 10",
+		},
+		{
+			name:        "Surrounded",
+			sourceEpoch: 6,
+			targetEpoch: 9,
+			signingRoot: _byteArray(testRoot2),
+			err:         "slashable: 6->9 is surrounded by 5->10",
+		},
+		{
+			name:        "Surrounding",
+			sourceEpoch: 4,
+			targetEpoch: 11,
+			signingRoot: _byteArray(testRoot2),
+			err:         "slashable: 4->11 surrounds 5->10",
+		},
+		{
+			name:        "EarlierTarget",
+			sourceEpoch: 5,
+			targetEpoch: 9,
+			signingRoot: _byteArray(testRoot2),
+			err:         "slashable: target epoch 9 is before signed target epoch 10",
+		},
+		{
+			name:        "Later",
+			sourceEpoch: 10,
+			targetEpoch: 11,
+			signingRoot: _byteArray(testRoot2),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := store.CheckAndRecordAttestation(_byteArray(testPubKey), test.sourceEpoch, test.targetEpoch, test.signingRoot)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				assert.True(t, errors.Is(err, slashingprotection.ErrSlashable))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStorePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	store, err := slashingprotection.NewStore(path, _byteArray(testGVR))
+	require.NoError(t, err)
+	require.NoError(t, store.CheckAndRecordBlock(_byteArray(testPubKey), 10, _byteArray(testRoot1)))
+	require.NoError(t, store.CheckAndRecordAttestation(_byteArray(testPubKey), 5, 10, _byteArray(testRoot2)))
+
+	// The file is a valid interchange.
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	_, err = slashingprotection.ParseInterchange(data, _byteArray(testGVR))
+	require.NoError(t, err)
+
+	reopened, err := slashingprotection.NewStore(path, _byteArray(testGVR))
+	require.NoError(t, err)
+	require.EqualError(t, reopened.CheckAndRecordBlock(_byteArray(testPubKey), 10, _byteArray(testRoot2)),
+		"slashable: double proposal at slot 10")
+	require.EqualError(t, reopened.CheckAndRecordAttestation(_byteArray(testPubKey), 6, 9, _byteArray(testRoot1)),
+		"slashable: 6->9 is surrounded by 5->10")
+}
+
+func TestStoreHighWatermark(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	store, err := slashingprotection.NewStore(path, _byteArray(testGVR))
+	require.NoError(t, err)
+
+	// Imported history is kept until the key signs.
+	input := `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x` + testGVR + `"},` +
+		`"data":[{"pubkey":"0x` + testPubKey + `","signed_blocks":[{"slot":"1"},{"slot":"2"}],` +
+		`"signed_attestations":[{"source_epoch":"0","target_epoch":"1"},{"source_epoch":"1","target_epoch":"2"}]}]}`
+	interchange, err := slashingprotection.ParseInterchange([]byte(input), _byteArray(testGVR))
+	require.NoError(t, err)
+	require.NoError(t, store.Import(interchange))
+	require.Len(t, store.Export().Records[0].SignedBlocks, 2)
+	require.Len(t, store.Export().Records[0].SignedAttestations, 2)
+
+	for i := uint64(3); i < 100; i++ {
+		require.NoError(t, store.CheckAndRecordBlock(_byteArray(testPubKey), i, _byteArray(testRoot1)))
+		require.NoError(t, store.CheckAndRecordAttestation(_byteArray(testPubKey), i-1, i, _byteArray(testRoot2)))
+	}
+
+	reopened, err := slashingprotection.NewStore(path, _byteArray(testGVR))
+	require.NoError(t, err)
+	record := reopened.Export().Records[0]
+	require.Len(t, record.SignedBlocks, 1)
+	assert.Equal(t, uint64(99), record.SignedBlocks[0].Slot)
+	require.Len(t, record.SignedAttestations, 1)
+	assert.Equal(t, uint64(98), record.SignedAttestations[0].SourceEpoch)
+	assert.Equal(t, uint64(99), record.SignedAttestations[0].TargetEpoch)
+
+	// The high watermark still protects against earlier and conflicting messages.
+	require.EqualError(t, reopened.CheckAndRecordBlock(_byteArray(testPubKey), 50, _byteArray(testRoot1)),
+		"slashable: slot 50 is before signed block at slot 99")
+	require.EqualError(t, reopened.CheckAndRecordAttestation(_byteArray(testPubKey), 98, 99, _byteArray(testRoot1)),
+		"slashable: double vote at target epoch 99")
+	require.EqualError(t, reopened.CheckAndRecordAttestation(_byteArray(testPubKey), 97, 100, _byteArray(testRoot1)),
+		"slashable: 97->100 surrounds 98->99")
+}
+
+func TestStoreImportExport(t *testing.T) {
+	store, err := slashingprotection.NewStore(filepath.Join(t.TempDir(), "store.json"), _byteArray(testGVR))
+	require.NoError(t, err)
+	require.NoError(t, store.CheckAndRecordBlock(_byteArray(testPubKey), 10, _byteArray(testRoot1)))
+
+	require.EqualError(t, store.Import(nil), "no interchange supplied")
+	require.ErrorContains(t, store.Import(&slashingprotection.Interchange{GenesisValidatorsRoot: make([]byte, 32)}),
+		"does not match")
+
+	// Import a conflicting interchange, which should be rejected in full.
+	conflicting := &slashingprotection.Interchange{
+		GenesisValidatorsRoot: _byteArray(testGVR),
+		Records: []*slashingprotection.Record{
+			{
+				PublicKey:    _byteArray(testPubKey),
+				SignedBlocks: []*slashingprotection.SignedBlock{{Slot: 10, SigningRoot: _byteArray(testRoot2)}},
+			},
+			{
+				PublicKey:    _byteArray(testPubKey2),
+				SignedBlocks: []*slashingprotection.SignedBlock{{Slot: 20}},
+			},
+		},
+	}
+	require.EqualError(t, store.Import(conflicting), "1 conflicts: 0x"+testPubKey+": double proposal at slot 10")
+	assert.Empty(t, store.Export(_byteArray(testPubKey2)).Records)
+
+	// Import history for a second key, and round-trip the store through JSON.
+	input := `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x` + testGVR + `"},` +
+		`"data":[{"pubkey":"0x` + testPubKey2 + `","signed_blocks":[{"slot":"20"}],` +
+		`"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]}]}`
+	interchange, err := slashingprotection.ParseInterchange([]byte(input), _byteArray(testGVR))
+	require.NoError(t, err)
+	require.NoError(t, store.Import(interchange))
+
+	require.EqualError(t, store.CheckAndRecordBlock(_byteArray(testPubKey2), 19, _byteArray(testRoot1)),
+		"slashable: slot 19 is before signed block at slot 20")
+
+	exported, err := json.Marshal(store.Export())
+	require.NoError(t, err)
+	assert.Equal(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x`+testGVR+`"},`+
+		`"data":[{"pubkey":"0x`+testPubKey+`","signed_blocks":[{"slot":"10","signing_root":"0x`+testRoot1+`"}],"signed_attestations":[]},`+
+		`{"pubkey":"0x`+testPubKey2+`","signed_blocks":[{"slot":"20"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"}]}]}`,
+		string(exported))
+
+	single := store.Export(_byteArray(testPubKey2))
+	require.Len(t, single.Records, 1)
+	assert.Equal(t, _byteArray(testPubKey2), single.Records[0].PublicKey)
+}
@@ -0,0 +1,61 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+)
+
+// Merkleize calculates the SSZ merkle root of a list of 32-byte chunks.
+// The chunks are padded with zero chunks to the next power of two.
+func Merkleize(chunks [][]byte) ([]byte, error) {
+	width := 1
+	for width < len(chunks) {
+		width *= 2
+	}
+
+	layer := make([][]byte, width)
+	for i := range layer {
+		if i >= len(chunks) {
+			layer[i] = make([]byte, 32)
+
+			continue
+		}
+		if len(chunks[i]) != 32 {
+			return nil, fmt.Errorf("chunk %d is %d bytes, must be 32", i, len(chunks[i]))
+		}
+		layer[i] = chunks[i]
+	}
+	for len(layer) > 1 {
+		next := make([][]byte, len(layer)/2)
+		for i := range next {
+			next[i] = SHA256(layer[2*i], layer[2*i+1])
+		}
+		layer = next
+	}
+
+	return layer[0], nil
+}
+
+// Pack splits data in to 32-byte chunks, with the final chunk padded with zeros.
+func Pack(data []byte) [][]byte {
+	chunks := make([][]byte, 0, (len(data)+31)/32)
+	for i := 0; i < len(data); i += 32 {
+		chunk := make([]byte, 32)
+		copy(chunk, data[i:])
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
@@ -0,0 +1,78 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestMerkleize(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks [][]byte
+		err    string
+		root   []byte
+	}{
+		{
+			name: "Nil",
+			root: make([]byte, 32),
+		},
+		{
+			name:   "BadChunk",
+			chunks: [][]byte{make([]byte, 32), make([]byte, 31)},
+			err:    "chunk 1 is 31 bytes, must be 32",
+		},
+		{
+			name:   "Single",
+			chunks: [][]byte{bytes.Repeat([]byte{0x01}, 32)},
+			root:   bytes.Repeat([]byte{0x01}, 32),
+		},
+		{
+			name:   "Two",
+			chunks: [][]byte{bytes.Repeat([]byte{0x01}, 32), bytes.Repeat([]byte{0x02}, 32)},
+			root:   _byteArray("f818afd37a6dc3bc92fb44731011277006db4efa6e9023cd7468c02335d22a4d"),
+		},
+		{
+			name:   "Padded",
+			chunks: [][]byte{bytes.Repeat([]byte{0x01}, 32), bytes.Repeat([]byte{0x02}, 32), bytes.Repeat([]byte{0x03}, 32)},
+			root:   _byteArray("d6cfa0d1046a0f4c1f9a6dc57afb0f4577680c106a48cf04125e7ba8606da219"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root, err := util.Merkleize(test.chunks)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.root, root)
+			}
+		})
+	}
+}
+
+func TestPack(t *testing.T) {
+	assert.Empty(t, util.Pack(nil))
+	assert.Equal(t, [][]byte{append([]byte{0x01, 0x02}, make([]byte, 30)...)}, util.Pack([]byte{0x01, 0x02}))
+	chunks := util.Pack(bytes.Repeat([]byte{0x01}, 33))
+	require.Len(t, chunks, 2)
+	assert.Equal(t, bytes.Repeat([]byte{0x01}, 32), chunks[0])
+	assert.Equal(t, append([]byte{0x01}, make([]byte, 31)...), chunks[1])
+}
@@ -0,0 +1,203 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	bls "github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// ThresholdKey is a private key split in to shares, along with Feldman commitments to the sharing polynomial.
+type ThresholdKey struct {
+	// Threshold is the number of shares required to generate a signature.
+	Threshold int
+	// PublicKey is the public key of the group.
+	PublicKey e2types.PublicKey
+	// Commitments are the public keys of the coefficients of the sharing polynomial.
+	Commitments [][]byte
+	// Shares are the key shares.
+	Shares []*ThresholdKeyShare
+}
+
+// ThresholdKeyShare is a single share of a threshold key.
+type ThresholdKeyShare struct {
+	// Index is the point at which the sharing polynomial was evaluated to create this share.
+	Index uint64
+	// PrivateKey is the private key share.
+	PrivateKey *e2types.BLSPrivateKey
+	// PublicKey is the public key share.
+	PublicKey e2types.PublicKey
+}
+
+// PartialSignature is a signature generated by a single threshold key share.
+type PartialSignature struct {
+	// Index is the index of the share that generated the signature.
+	Index uint64
+	// Signature is the signature.
+	Signature e2types.Signature
+}
+
+// SplitThresholdKey splits a private key into shares, any threshold of which can generate signatures for the key.
+func SplitThresholdKey(sk *e2types.BLSPrivateKey, threshold int, shares int) (*ThresholdKey, error) {
+	if sk == nil {
+		return nil, errors.New("no key supplied")
+	}
+	if threshold < 1 {
+		return nil, errors.New("threshold must be at least 1")
+	}
+	if shares < threshold {
+		return nil, errors.New("shares must be at least threshold")
+	}
+
+	coefficients, err := randomPolynomial(osToIP(sk.Marshal()), threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	return thresholdKeyFromPolynomial(coefficients, shares)
+}
+
+// VerifyThresholdKeyShare verifies that a private key share is consistent with the commitments to the sharing polynomial.
+func VerifyThresholdKeyShare(share *ThresholdKeyShare, commitments [][]byte) error {
+	if share == nil || share.PrivateKey == nil {
+		return errors.New("no share supplied")
+	}
+	pubKey, err := PublicKeyShare(share.Index, commitments)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(share.PrivateKey.PublicKey().Marshal(), pubKey.Marshal()) {
+		return fmt.Errorf("share %d does not match commitments", share.Index)
+	}
+
+	return nil
+}
+
+// PublicKeyShare calculates the public key of the share at the given index from the commitments to the sharing polynomial.
+func PublicKeyShare(index uint64, commitments [][]byte) (e2types.PublicKey, error) {
+	if index == 0 {
+		return nil, errors.New("index must be at least 1")
+	}
+	if len(commitments) == 0 {
+		return nil, errors.New("no commitments supplied")
+	}
+
+	mpk := make([]bls.PublicKey, len(commitments))
+	for i := range commitments {
+		if err := mpk[i].Deserialize(commitments[i]); err != nil {
+			return nil, errors.Wrapf(err, "invalid commitment %d", i)
+		}
+	}
+	id, err := blsID(index)
+	if err != nil {
+		return nil, err
+	}
+	var pubKey bls.PublicKey
+	if err := pubKey.Set(mpk, id); err != nil {
+		return nil, errors.Wrap(err, "failed to evaluate commitments")
+	}
+
+	return e2types.BLSPublicKeyFromBytes(pubKey.Serialize())
+}
+
+// RecoverSignature recovers the signature of the group from partial signatures using Lagrange interpolation.
+// At least threshold partial signatures from distinct shares must be supplied for the result to be valid.
+func RecoverSignature(partials []*PartialSignature) (e2types.Signature, error) {
+	if len(partials) == 0 {
+		return nil, errors.New("no partial signatures supplied")
+	}
+
+	sigs := make([]bls.Sign, len(partials))
+	ids := make([]bls.ID, len(partials))
+	seen := make(map[uint64]bool, len(partials))
+	for i, partial := range partials {
+		if partial == nil || partial.Signature == nil {
+			return nil, fmt.Errorf("partial signature %d missing", i)
+		}
+		if seen[partial.Index] {
+			return nil, fmt.Errorf("duplicate partial signature index %d", partial.Index)
+		}
+		seen[partial.Index] = true
+		if err := sigs[i].Deserialize(partial.Signature.Marshal()); err != nil {
+			return nil, errors.Wrapf(err, "invalid partial signature %d", i)
+		}
+		id, err := blsID(partial.Index)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = *id
+	}
+
+	var sig bls.Sign
+	if err := sig.Recover(sigs, ids); err != nil {
+		return nil, errors.Wrap(err, "failed to recover signature")
+	}
+
+	return e2types.BLSSignatureFromSig(sig)
+}
+
+// thresholdKeyFromPolynomial creates a threshold key from the coefficients of its sharing polynomial.
+func thresholdKeyFromPolynomial(coefficients []*big.Int, shares int) (*ThresholdKey, error) {
+	commitments := make([][]byte, len(coefficients))
+	for i := range coefficients {
+		coefficient, err := e2types.BLSPrivateKeyFromBytes(i2OSP(coefficients[i], 32))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid coefficient %d", i)
+		}
+		commitments[i] = coefficient.PublicKey().Marshal()
+	}
+	pubKey, err := e2types.BLSPublicKeyFromBytes(commitments[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid group public key")
+	}
+
+	res := &ThresholdKey{
+		Threshold:   len(coefficients),
+		PublicKey:   pubKey,
+		Commitments: commitments,
+		Shares:      make([]*ThresholdKeyShare, shares),
+	}
+	for i := range res.Shares {
+		index := uint64(i + 1)
+		sk, err := e2types.BLSPrivateKeyFromBytes(i2OSP(evaluatePolynomial(coefficients, new(big.Int).SetUint64(index)), 32))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid share %d", index)
+		}
+		res.Shares[i] = &ThresholdKeyShare{
+			Index:      index,
+			PrivateKey: sk,
+			PublicKey:  sk.PublicKey(),
+		}
+	}
+
+	return res, nil
+}
+
+// blsID creates a BLS library identifier for a share index.
+func blsID(index uint64) (*bls.ID, error) {
+	var id bls.ID
+	if err := id.SetDecString(strconv.FormatUint(index, 10)); err != nil {
+		return nil, errors.Wrapf(err, "invalid index %d", index)
+	}
+
+	return &id, nil
+}
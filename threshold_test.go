@@ -0,0 +1,178 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestSplitThresholdKey(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	key, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		threshold int
+		shares    int
+		err       string
+	}{
+		{
+			name:      "ThresholdZero",
+			threshold: 0,
+			shares:    3,
+			err:       "threshold must be at least 1",
+		},
+		{
+			name:      "SharesBelowThreshold",
+			threshold: 3,
+			shares:    2,
+			err:       "shares must be at least threshold",
+		},
+		{
+			name:      "TwoOfThree",
+			threshold: 2,
+			shares:    3,
+		},
+		{
+			name:      "ThreeOfFive",
+			threshold: 3,
+			shares:    5,
+		},
+	}
+
+	msg := util.SHA256([]byte("message"))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			thresholdKey, err := util.SplitThresholdKey(key, test.threshold, test.shares)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, key.PublicKey().Marshal(), thresholdKey.PublicKey.Marshal())
+			require.Len(t, thresholdKey.Commitments, test.threshold)
+			require.Len(t, thresholdKey.Shares, test.shares)
+
+			partials := make([]*util.PartialSignature, 0, len(thresholdKey.Shares))
+			for _, share := range thresholdKey.Shares {
+				require.NoError(t, util.VerifyThresholdKeyShare(share, thresholdKey.Commitments))
+				pubKey, err := util.PublicKeyShare(share.Index, thresholdKey.Commitments)
+				require.NoError(t, err)
+				assert.Equal(t, share.PublicKey.Marshal(), pubKey.Marshal())
+				partials = append(partials, &util.PartialSignature{
+					Index:     share.Index,
+					Signature: share.PrivateKey.Sign(msg),
+				})
+			}
+
+			// Any threshold partial signatures recover the group signature.
+			expected := key.Sign(msg).Marshal()
+			for i := 0; i+test.threshold <= len(partials); i++ {
+				sig, err := util.RecoverSignature(partials[i : i+test.threshold])
+				require.NoError(t, err)
+				assert.Equal(t, expected, sig.Marshal())
+				assert.True(t, sig.Verify(msg, thresholdKey.PublicKey))
+			}
+
+			// Fewer than threshold partial signatures do not.
+			sig, err := util.RecoverSignature(partials[:test.threshold-1])
+			require.NoError(t, err)
+			assert.NotEqual(t, expected, sig.Marshal())
+		})
+	}
+}
+
+func TestVerifyThresholdKeyShare(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	key, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	thresholdKey, err := util.SplitThresholdKey(key, 2, 3)
+	require.NoError(t, err)
+	other, err := util.SplitThresholdKey(key, 2, 3)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		share       *util.ThresholdKeyShare
+		commitments [][]byte
+		err         string
+	}{
+		{
+			name:        "Nil",
+			commitments: thresholdKey.Commitments,
+			err:         "no share supplied",
+		},
+		{
+			name:  "NoCommitments",
+			share: thresholdKey.Shares[0],
+			err:   "no commitments supplied",
+		},
+		{
+			name:        "ZeroIndex",
+			share:       &util.ThresholdKeyShare{PrivateKey: key},
+			commitments: thresholdKey.Commitments,
+			err:         "index must be at least 1",
+		},
+		{
+			name:        "BadCommitment",
+			share:       thresholdKey.Shares[0],
+			commitments: [][]byte{thresholdKey.Commitments[0], {0x01}},
+			err:         "invalid commitment 1: err blsPublicKeyDeserialize 01",
+		},
+		{
+			name:        "Mismatch",
+			share:       other.Shares[1],
+			commitments: thresholdKey.Commitments,
+			err:         "share 2 does not match commitments",
+		},
+		{
+			name:        "Good",
+			share:       thresholdKey.Shares[1],
+			commitments: thresholdKey.Commitments,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := util.VerifyThresholdKeyShare(test.share, test.commitments)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRecoverSignatureErrors(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	key, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	sig := key.Sign(util.SHA256([]byte("message")))
+
+	_, err = util.RecoverSignature(nil)
+	require.EqualError(t, err, "no partial signatures supplied")
+	_, err = util.RecoverSignature([]*util.PartialSignature{{Index: 1}})
+	require.EqualError(t, err, "partial signature 0 missing")
+	_, err = util.RecoverSignature([]*util.PartialSignature{{Index: 1, Signature: sig}, {Index: 1, Signature: sig}})
+	require.EqualError(t, err, "duplicate partial signature index 1")
+}
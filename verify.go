@@ -0,0 +1,161 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ValidatorRecord is the public key and withdrawal credentials of a validator, as found on chain.
+type ValidatorRecord struct {
+	PublicKey             []byte
+	WithdrawalCredentials []byte
+}
+
+// ValidatorOwnership reports which keys of a validator are controlled by a seed.
+type ValidatorOwnership struct {
+	Validator *ValidatorRecord
+	// SigningPath is the path of the validator's signing key, or "" if the seed does not control it.
+	SigningPath string
+	// WithdrawalPath is the path of the validator's BLS withdrawal key, or "" if the seed does not control it
+	// or the validator does not have BLS withdrawal credentials.
+	WithdrawalPath string
+}
+
+// SigningKeyControlled returns true if the seed controls the validator's signing key.
+func (o *ValidatorOwnership) SigningKeyControlled() bool {
+	return o.SigningPath != ""
+}
+
+// WithdrawalKeyControlled returns true if the seed controls the validator's BLS withdrawal key.
+func (o *ValidatorOwnership) WithdrawalKeyControlled() bool {
+	return o.WithdrawalPath != ""
+}
+
+// VerifyMnemonic confirms which validators are controlled by the keys derived from a seed.
+// The signing and withdrawal keys of accounts 0 to maxAccounts-1 are derived in parallel, and matched against
+// the validators' public keys and, for validators with BLS withdrawal credentials, their withdrawal credentials.
+// The results are returned in the same order as the validators.
+func VerifyMnemonic(ctx context.Context,
+	seed []byte,
+	validators []*ValidatorRecord,
+	maxAccounts uint32,
+) (
+	[]*ValidatorOwnership,
+	error,
+) {
+	if len(validators) == 0 {
+		return nil, errors.New("no validators supplied")
+	}
+	deriver, err := NewKeyDeriver(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ValidatorOwnership, len(validators))
+	signingKeys := make(map[string][]int)
+	withdrawalCredentials := make(map[string][]int)
+	for i, validator := range validators {
+		if validator == nil {
+			return nil, fmt.Errorf("validator %d not supplied", i)
+		}
+		if len(validator.PublicKey) != 48 {
+			return nil, fmt.Errorf("public key of validator %d must be 48 bytes", i)
+		}
+		if len(validator.WithdrawalCredentials) != 32 {
+			return nil, fmt.Errorf("withdrawal credentials of validator %d must be 32 bytes", i)
+		}
+		res[i] = &ValidatorOwnership{Validator: validator}
+		signingKeys[string(validator.PublicKey)] = append(signingKeys[string(validator.PublicKey)], i)
+		if validator.WithdrawalCredentials[0] == BLSWithdrawalPrefix {
+			key := string(validator.WithdrawalCredentials)
+			withdrawalCredentials[key] = append(withdrawalCredentials[key], i)
+		}
+	}
+	remaining := len(signingKeys) + len(withdrawalCredentials)
+
+	searchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	accounts := make(chan uint32)
+	go func() {
+		defer close(accounts)
+		for account := uint32(0); account < maxAccounts; account++ {
+			select {
+			case accounts <- account:
+			case <-searchCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var mu sync.Mutex
+	var once sync.Once
+	var resErr error
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for account := range accounts {
+				keys, err := deriver.AccountPublicKeys(account)
+				if err != nil {
+					once.Do(func() {
+						resErr = err
+						cancel()
+					})
+
+					return
+				}
+				mu.Lock()
+				if indices, exists := signingKeys[string(keys.SigningPublicKey)]; exists {
+					for _, index := range indices {
+						res[index].SigningPath = keys.SigningPath
+					}
+					delete(signingKeys, string(keys.SigningPublicKey))
+					remaining--
+				}
+				if indices, exists := withdrawalCredentials[string(keys.WithdrawalCredentials)]; exists {
+					for _, index := range indices {
+						res[index].WithdrawalPath = keys.WithdrawalPath
+					}
+					delete(withdrawalCredentials, string(keys.WithdrawalCredentials))
+					remaining--
+				}
+				if remaining == 0 {
+					// Every key has been found, so there is no need to search further accounts.
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	switch {
+	case resErr != nil:
+		return nil, resErr
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	default:
+		return res, nil
+	}
+}
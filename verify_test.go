@@ -0,0 +1,130 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cgo
+
+package util_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+func TestVerifyMnemonic(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	pubKey := func(path string) []byte {
+		key, err := util.PublicKeyFromSeedAndPath(seed, path)
+		require.NoError(t, err)
+
+		return key.Marshal()
+	}
+	credentials := func(path string) []byte {
+		res, err := util.BLSWithdrawalCredentials(pubKey(path))
+		require.NoError(t, err)
+
+		return res
+	}
+	executionCredentials := _byteArray("010000000000000000000000000000000000000000000000000000000000abcd")
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		seed       []byte
+		validators []*util.ValidatorRecord
+		err        string
+		signing    []string
+		withdrawal []string
+	}{
+		{
+			name: "ValidatorsMissing",
+			ctx:  context.Background(),
+			seed: seed,
+			err:  "no validators supplied",
+		},
+		{
+			name:       "SeedShort",
+			ctx:        context.Background(),
+			seed:       seed[:15],
+			validators: []*util.ValidatorRecord{{PublicKey: make([]byte, 48), WithdrawalCredentials: make([]byte, 32)}},
+			err:        "seed must be at least 128 bits",
+		},
+		{
+			name:       "ValidatorNil",
+			ctx:        context.Background(),
+			seed:       seed,
+			validators: []*util.ValidatorRecord{nil},
+			err:        "validator 0 not supplied",
+		},
+		{
+			name:       "PublicKeyInvalid",
+			ctx:        context.Background(),
+			seed:       seed,
+			validators: []*util.ValidatorRecord{{PublicKey: make([]byte, 47), WithdrawalCredentials: make([]byte, 32)}},
+			err:        "public key of validator 0 must be 48 bytes",
+		},
+		{
+			name:       "WithdrawalCredentialsInvalid",
+			ctx:        context.Background(),
+			seed:       seed,
+			validators: []*util.ValidatorRecord{{PublicKey: make([]byte, 48), WithdrawalCredentials: make([]byte, 31)}},
+			err:        "withdrawal credentials of validator 0 must be 32 bytes",
+		},
+		{
+			name:       "Cancelled",
+			ctx:        cancelledCtx,
+			seed:       seed,
+			validators: []*util.ValidatorRecord{{PublicKey: make([]byte, 48), WithdrawalCredentials: make([]byte, 32)}},
+			err:        "context canceled",
+		},
+		{
+			name: "Good",
+			ctx:  context.Background(),
+			seed: seed,
+			validators: []*util.ValidatorRecord{
+				{PublicKey: pubKey("m/12381/3600/2/0/0"), WithdrawalCredentials: credentials("m/12381/3600/2/0")},
+				{PublicKey: pubKey("m/12381/3600/5/0/0"), WithdrawalCredentials: executionCredentials},
+				{PublicKey: pubKey("m/12381/60/0/0"), WithdrawalCredentials: credentials("m/12381/3600/3/0")},
+				{PublicKey: pubKey("m/12381/60/1/0"), WithdrawalCredentials: credentials("m/12381/60/1")},
+				{PublicKey: pubKey("m/12381/3600/2/0/0"), WithdrawalCredentials: credentials("m/12381/3600/2/0")},
+				{PublicKey: pubKey("m/12381/3600/20/0/0"), WithdrawalCredentials: credentials("m/12381/3600/20/0")},
+			},
+			signing:    []string{"m/12381/3600/2/0/0", "m/12381/3600/5/0/0", "", "", "m/12381/3600/2/0/0", ""},
+			withdrawal: []string{"m/12381/3600/2/0", "", "m/12381/3600/3/0", "", "m/12381/3600/2/0", ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.VerifyMnemonic(test.ctx, test.seed, test.validators, 10)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, res, len(test.validators))
+				for i := range res {
+					assert.Equal(t, test.validators[i], res[i].Validator)
+					assert.Equal(t, test.signing[i], res[i].SigningPath)
+					assert.Equal(t, test.signing[i] != "", res[i].SigningKeyControlled())
+					assert.Equal(t, test.withdrawal[i], res[i].WithdrawalPath)
+					assert.Equal(t, test.withdrawal[i] != "", res[i].WithdrawalKeyControlled())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,621 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web3signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"strconv"
+
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+const (
+	// maxValidatorsPerCommittee is the maximum number of validators in an attestation committee.
+	maxValidatorsPerCommittee = 2048
+	// maxCommitteesPerSlot is the maximum number of attestation committees in a slot.
+	maxCommitteesPerSlot = 64
+	// syncSubcommitteeSize is the number of validators in a sync subcommittee.
+	syncSubcommitteeSize = util.SyncCommitteeSize / util.SyncCommitteeSubnetCount
+	// depositProofLength is the number of hashes in the merkle proof of a deposit.
+	depositProofLength = 33
+
+	maxProposerSlashings = 16
+	maxAttesterSlashings = 2
+	maxAttestations      = 128
+	maxDeposits          = 16
+	maxVoluntaryExits    = 16
+)
+
+type attestationJSON struct {
+	AggregationBits hexBytes             `json:"aggregation_bits"`
+	Data            *attestationDataJSON `json:"data"`
+	Signature       hexBytes             `json:"signature"`
+	// CommitteeBits is present from Electra.
+	CommitteeBits hexBytes `json:"committee_bits"`
+}
+
+type aggregateAndProofJSON struct {
+	AggregatorIndex uint64           `json:"aggregator_index,string"`
+	Aggregate       *attestationJSON `json:"aggregate"`
+	SelectionProof  hexBytes         `json:"selection_proof"`
+}
+
+type versionedAggregateAndProofJSON struct {
+	Version string                 `json:"version"`
+	Data    *aggregateAndProofJSON `json:"data"`
+}
+
+type syncCommitteeContributionJSON struct {
+	Slot              uint64   `json:"slot,string"`
+	BeaconBlockRoot   hexBytes `json:"beacon_block_root"`
+	SubcommitteeIndex uint64   `json:"subcommittee_index,string"`
+	AggregationBits   hexBytes `json:"aggregation_bits"`
+	Signature         hexBytes `json:"signature"`
+}
+
+type contributionAndProofJSON struct {
+	AggregatorIndex uint64                         `json:"aggregator_index,string"`
+	Contribution    *syncCommitteeContributionJSON `json:"contribution"`
+	SelectionProof  hexBytes                       `json:"selection_proof"`
+}
+
+type validatorRegistrationJSON struct {
+	FeeRecipient hexBytes `json:"fee_recipient"`
+	GasLimit     uint64   `json:"gas_limit,string"`
+	Timestamp    uint64   `json:"timestamp,string"`
+	PublicKey    hexBytes `json:"pubkey"`
+}
+
+type phase0BeaconBlockJSON struct {
+	Slot          uint64                     `json:"slot,string"`
+	ProposerIndex uint64                     `json:"proposer_index,string"`
+	ParentRoot    hexBytes                   `json:"parent_root"`
+	StateRoot     hexBytes                   `json:"state_root"`
+	Body          *phase0BeaconBlockBodyJSON `json:"body"`
+}
+
+type phase0BeaconBlockBodyJSON struct {
+	RandaoReveal      hexBytes                   `json:"randao_reveal"`
+	ETH1Data          *eth1DataJSON              `json:"eth1_data"`
+	Graffiti          hexBytes                   `json:"graffiti"`
+	ProposerSlashings []*proposerSlashingJSON    `json:"proposer_slashings"`
+	AttesterSlashings []*attesterSlashingJSON    `json:"attester_slashings"`
+	Attestations      []*attestationJSON         `json:"attestations"`
+	Deposits          []*blockDepositJSON        `json:"deposits"`
+	VoluntaryExits    []*signedVoluntaryExitJSON `json:"voluntary_exits"`
+}
+
+type eth1DataJSON struct {
+	DepositRoot  hexBytes `json:"deposit_root"`
+	DepositCount uint64   `json:"deposit_count,string"`
+	BlockHash    hexBytes `json:"block_hash"`
+}
+
+type signedBeaconBlockHeaderJSON struct {
+	Message   *beaconBlockHeaderJSON `json:"message"`
+	Signature hexBytes               `json:"signature"`
+}
+
+type proposerSlashingJSON struct {
+	SignedHeader1 *signedBeaconBlockHeaderJSON `json:"signed_header_1"`
+	SignedHeader2 *signedBeaconBlockHeaderJSON `json:"signed_header_2"`
+}
+
+type indexedAttestationJSON struct {
+	AttestingIndices []string             `json:"attesting_indices"`
+	Data             *attestationDataJSON `json:"data"`
+	Signature        hexBytes             `json:"signature"`
+}
+
+type attesterSlashingJSON struct {
+	Attestation1 *indexedAttestationJSON `json:"attestation_1"`
+	Attestation2 *indexedAttestationJSON `json:"attestation_2"`
+}
+
+type blockDepositJSON struct {
+	Proof []hexBytes            `json:"proof"`
+	Data  *blockDepositDataJSON `json:"data"`
+}
+
+type blockDepositDataJSON struct {
+	PublicKey             hexBytes `json:"pubkey"`
+	WithdrawalCredentials hexBytes `json:"withdrawal_credentials"`
+	Amount                uint64   `json:"amount,string"`
+	Signature             hexBytes `json:"signature"`
+}
+
+type signedVoluntaryExitJSON struct {
+	Message   *voluntaryExitJSON `json:"message"`
+	Signature hexBytes           `json:"signature"`
+}
+
+// preElectraVersions are the fork versions prior to Electra, whose attestations do not have committee bits.
+//
+//nolint:gochecknoglobals
+var preElectraVersions = map[string]bool{
+	"PHASE0":    true,
+	"ALTAIR":    true,
+	"BELLATRIX": true,
+	"CAPELLA":   true,
+	"DENEB":     true,
+}
+
+// decodeAggregateAndProof decodes an aggregate and proof, which is versioned for AGGREGATE_AND_PROOF_V2.
+func decodeAggregateAndProof(input json.RawMessage, versioned bool) (*aggregateAndProofJSON, bool, error) {
+	if len(input) == 0 || string(input) == "null" {
+		return nil, false, nil
+	}
+	if !versioned {
+		var res aggregateAndProofJSON
+		if err := json.Unmarshal(input, &res); err != nil {
+			return nil, false, err
+		}
+
+		return &res, false, nil
+	}
+	var res versionedAggregateAndProofJSON
+	if err := json.Unmarshal(input, &res); err != nil {
+		return nil, false, err
+	}
+	if res.Version == "" {
+		return nil, false, fmt.Errorf("version missing")
+	}
+
+	return res.Data, !preElectraVersions[res.Version], nil
+}
+
+// hashTreeRoot returns the hash tree root of an aggregate and proof.
+func (a *aggregateAndProofJSON) hashTreeRoot(electra bool) ([]byte, error) {
+	if a.Aggregate == nil {
+		return nil, fmt.Errorf("aggregate missing")
+	}
+	aggregateRoot, err := a.Aggregate.hashTreeRoot(electra)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregate: %w", err)
+	}
+	selectionProofRoot, err := vectorRoot(a.SelectionProof, 96, "selection proof")
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Merkleize([][]byte{
+		uint64Root(a.AggregatorIndex),
+		aggregateRoot,
+		selectionProofRoot,
+	})
+}
+
+// hashTreeRoot returns the hash tree root of an attestation, with the Electra layout if requested.
+func (a *attestationJSON) hashTreeRoot(electra bool) ([]byte, error) {
+	aggregationBitsLimit := maxValidatorsPerCommittee
+	if electra {
+		aggregationBitsLimit *= maxCommitteesPerSlot
+	}
+	aggregationBitsRoot, err := bitlistRoot(a.AggregationBits, aggregationBitsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregation bits: %w", err)
+	}
+	dataRoot, err := attestationDataRoot(a.Data)
+	if err != nil {
+		return nil, err
+	}
+	signatureRoot, err := vectorRoot(a.Signature, 96, "signature")
+	if err != nil {
+		return nil, err
+	}
+	fields := [][]byte{
+		aggregationBitsRoot,
+		dataRoot,
+		signatureRoot,
+	}
+	if electra {
+		committeeBitsRoot, err := bitvectorRoot(a.CommitteeBits, maxCommitteesPerSlot)
+		if err != nil {
+			return nil, fmt.Errorf("invalid committee bits: %w", err)
+		}
+		fields = append(fields, committeeBitsRoot)
+	}
+
+	return util.Merkleize(fields)
+}
+
+// attestationDataRoot returns the hash tree root of attestation data.
+func attestationDataRoot(data *attestationDataJSON) ([]byte, error) {
+	if data == nil {
+		return nil, fmt.Errorf("attestation data missing")
+	}
+	if data.Source == nil || data.Target == nil {
+		return nil, fmt.Errorf("attestation checkpoints missing")
+	}
+
+	return (&util.AttestationData{
+		Slot:            data.Slot,
+		Index:           data.Index,
+		BeaconBlockRoot: data.BeaconBlockRoot,
+		Source:          &util.Checkpoint{Epoch: data.Source.Epoch, Root: data.Source.Root},
+		Target:          &util.Checkpoint{Epoch: data.Target.Epoch, Root: data.Target.Root},
+	}).HashTreeRoot()
+}
+
+// hashTreeRoot returns the hash tree root of a contribution and proof.
+func (c *contributionAndProofJSON) hashTreeRoot() ([]byte, error) {
+	if c.Contribution == nil {
+		return nil, fmt.Errorf("contribution missing")
+	}
+	contributionRoot, err := c.Contribution.hashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("invalid contribution: %w", err)
+	}
+	selectionProofRoot, err := vectorRoot(c.SelectionProof, 96, "selection proof")
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Merkleize([][]byte{
+		uint64Root(c.AggregatorIndex),
+		contributionRoot,
+		selectionProofRoot,
+	})
+}
+
+// hashTreeRoot returns the hash tree root of a sync committee contribution.
+func (c *syncCommitteeContributionJSON) hashTreeRoot() ([]byte, error) {
+	if len(c.BeaconBlockRoot) != 32 {
+		return nil, fmt.Errorf("beacon block root must be 32 bytes")
+	}
+	aggregationBitsRoot, err := bitvectorRoot(c.AggregationBits, syncSubcommitteeSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregation bits: %w", err)
+	}
+	signatureRoot, err := vectorRoot(c.Signature, 96, "signature")
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Merkleize([][]byte{
+		uint64Root(c.Slot),
+		c.BeaconBlockRoot,
+		uint64Root(c.SubcommitteeIndex),
+		aggregationBitsRoot,
+		signatureRoot,
+	})
+}
+
+// hashTreeRoot returns the hash tree root of a validator registration.
+func (v *validatorRegistrationJSON) hashTreeRoot() ([]byte, error) {
+	feeRecipientRoot, err := vectorRoot(v.FeeRecipient, 20, "fee recipient")
+	if err != nil {
+		return nil, err
+	}
+	pubKeyRoot, err := vectorRoot(v.PublicKey, 48, "public key")
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Merkleize([][]byte{
+		feeRecipientRoot,
+		uint64Root(v.GasLimit),
+		uint64Root(v.Timestamp),
+		pubKeyRoot,
+	})
+}
+
+// header returns the header of a phase 0 beacon block, which has the same hash tree root as the block.
+func (b *phase0BeaconBlockJSON) header() (*util.BeaconBlockHeader, error) {
+	if b.Body == nil {
+		return nil, fmt.Errorf("body missing")
+	}
+	bodyRoot, err := b.Body.hashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("invalid body: %w", err)
+	}
+
+	return &util.BeaconBlockHeader{
+		Slot:          b.Slot,
+		ProposerIndex: b.ProposerIndex,
+		ParentRoot:    b.ParentRoot,
+		StateRoot:     b.StateRoot,
+		BodyRoot:      bodyRoot,
+	}, nil
+}
+
+// hashTreeRoot returns the hash tree root of a phase 0 beacon block body.
+func (b *phase0BeaconBlockBodyJSON) hashTreeRoot() ([]byte, error) {
+	randaoRevealRoot, err := vectorRoot(b.RandaoReveal, 96, "randao reveal")
+	if err != nil {
+		return nil, err
+	}
+	if b.ETH1Data == nil {
+		return nil, fmt.Errorf("eth1 data missing")
+	}
+	if len(b.ETH1Data.DepositRoot) != 32 || len(b.ETH1Data.BlockHash) != 32 {
+		return nil, fmt.Errorf("eth1 data roots must be 32 bytes")
+	}
+	eth1DataRoot, err := util.Merkleize([][]byte{
+		b.ETH1Data.DepositRoot,
+		uint64Root(b.ETH1Data.DepositCount),
+		b.ETH1Data.BlockHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(b.Graffiti) != 32 {
+		return nil, fmt.Errorf("graffiti must be 32 bytes")
+	}
+
+	proposerSlashingsRoot, err := containerListRoot(len(b.ProposerSlashings), maxProposerSlashings, "proposer slashing",
+		func(i int) ([]byte, error) {
+			return b.ProposerSlashings[i].hashTreeRoot()
+		})
+	if err != nil {
+		return nil, err
+	}
+	attesterSlashingsRoot, err := containerListRoot(len(b.AttesterSlashings), maxAttesterSlashings, "attester slashing",
+		func(i int) ([]byte, error) {
+			return b.AttesterSlashings[i].hashTreeRoot()
+		})
+	if err != nil {
+		return nil, err
+	}
+	attestationsRoot, err := containerListRoot(len(b.Attestations), maxAttestations, "attestation",
+		func(i int) ([]byte, error) {
+			if b.Attestations[i] == nil {
+				return nil, fmt.Errorf("missing")
+			}
+
+			return b.Attestations[i].hashTreeRoot(false)
+		})
+	if err != nil {
+		return nil, err
+	}
+	depositsRoot, err := containerListRoot(len(b.Deposits), maxDeposits, "deposit",
+		func(i int) ([]byte, error) {
+			return b.Deposits[i].hashTreeRoot()
+		})
+	if err != nil {
+		return nil, err
+	}
+	voluntaryExitsRoot, err := containerListRoot(len(b.VoluntaryExits), maxVoluntaryExits, "voluntary exit",
+		func(i int) ([]byte, error) {
+			return b.VoluntaryExits[i].hashTreeRoot()
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Merkleize([][]byte{
+		randaoRevealRoot,
+		eth1DataRoot,
+		b.Graffiti,
+		proposerSlashingsRoot,
+		attesterSlashingsRoot,
+		attestationsRoot,
+		depositsRoot,
+		voluntaryExitsRoot,
+	})
+}
+
+// hashTreeRoot returns the hash tree root of a signed beacon block header.
+func (s *signedBeaconBlockHeaderJSON) hashTreeRoot() ([]byte, error) {
+	if s == nil || s.Message == nil {
+		return nil, fmt.Errorf("header missing")
+	}
+	headerRoot, err := (&util.BeaconBlockHeader{
+		Slot:          s.Message.Slot,
+		ProposerIndex: s.Message.ProposerIndex,
+		ParentRoot:    s.Message.ParentRoot,
+		StateRoot:     s.Message.StateRoot,
+		BodyRoot:      s.Message.BodyRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	signatureRoot, err := vectorRoot(s.Signature, 96, "signature")
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Merkleize([][]byte{headerRoot, signatureRoot})
+}
+
+// hashTreeRoot returns the hash tree root of a proposer slashing.
+func (p *proposerSlashingJSON) hashTreeRoot() ([]byte, error) {
+	if p == nil {
+		return nil, fmt.Errorf("missing")
+	}
+	header1Root, err := p.SignedHeader1.hashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	header2Root, err := p.SignedHeader2.hashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Merkleize([][]byte{header1Root, header2Root})
+}
+
+// hashTreeRoot returns the hash tree root of an indexed attestation.
+func (a *indexedAttestationJSON) hashTreeRoot() ([]byte, error) {
+	if a == nil {
+		return nil, fmt.Errorf("attestation missing")
+	}
+	if len(a.AttestingIndices) > maxValidatorsPerCommittee {
+		return nil, fmt.Errorf("too many attesting indices")
+	}
+	indices := make([]byte, 0, 8*len(a.AttestingIndices))
+	for _, str := range a.AttestingIndices {
+		index, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attesting index %q", str)
+		}
+		indices = append(indices, uint64Root(index)[:8]...)
+	}
+	indicesRoot, err := listRoot(util.Pack(indices), maxValidatorsPerCommittee*8/32, uint64(len(a.AttestingIndices)))
+	if err != nil {
+		return nil, err
+	}
+	dataRoot, err := attestationDataRoot(a.Data)
+	if err != nil {
+		return nil, err
+	}
+	signatureRoot, err := vectorRoot(a.Signature, 96, "signature")
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Merkleize([][]byte{indicesRoot, dataRoot, signatureRoot})
+}
+
+// hashTreeRoot returns the hash tree root of an attester slashing.
+func (a *attesterSlashingJSON) hashTreeRoot() ([]byte, error) {
+	if a == nil {
+		return nil, fmt.Errorf("missing")
+	}
+	attestation1Root, err := a.Attestation1.hashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	attestation2Root, err := a.Attestation2.hashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Merkleize([][]byte{attestation1Root, attestation2Root})
+}
+
+// hashTreeRoot returns the hash tree root of a deposit in a block.
+func (d *blockDepositJSON) hashTreeRoot() ([]byte, error) {
+	if d == nil || d.Data == nil {
+		return nil, fmt.Errorf("missing")
+	}
+	if len(d.Proof) != depositProofLength {
+		return nil, fmt.Errorf("proof must have %d hashes", depositProofLength)
+	}
+	proof := make([][]byte, len(d.Proof))
+	for i := range d.Proof {
+		if len(d.Proof[i]) != 32 {
+			return nil, fmt.Errorf("proof hashes must be 32 bytes")
+		}
+		proof[i] = d.Proof[i]
+	}
+	proofRoot, err := util.Merkleize(proof)
+	if err != nil {
+		return nil, err
+	}
+	dataRoot, err := (&util.DepositData{
+		PublicKey:             d.Data.PublicKey,
+		WithdrawalCredentials: d.Data.WithdrawalCredentials,
+		Amount:                util.Gwei(d.Data.Amount),
+		Signature:             d.Data.Signature,
+	}).HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Merkleize([][]byte{proofRoot, dataRoot})
+}
+
+// hashTreeRoot returns the hash tree root of a signed voluntary exit.
+func (s *signedVoluntaryExitJSON) hashTreeRoot() ([]byte, error) {
+	if s == nil || s.Message == nil {
+		return nil, fmt.Errorf("missing")
+	}
+	signatureRoot, err := vectorRoot(s.Signature, 96, "signature")
+	if err != nil {
+		return nil, err
+	}
+
+	return util.Merkleize([][]byte{
+		util.SHA256(uint64Root(s.Message.Epoch), uint64Root(s.Message.ValidatorIndex)),
+		signatureRoot,
+	})
+}
+
+// vectorRoot returns the hash tree root of a fixed-length byte vector.
+func vectorRoot(data []byte, length int, name string) ([]byte, error) {
+	if len(data) != length {
+		return nil, fmt.Errorf("%s must be %d bytes", name, length)
+	}
+
+	return util.Merkleize(util.Pack(data))
+}
+
+// containerListRoot returns the hash tree root of a list of containers, given a function to obtain the root of
+// each container.
+func containerListRoot(length int, limit int, name string, root func(i int) ([]byte, error)) ([]byte, error) {
+	if length > limit {
+		return nil, fmt.Errorf("too many %ss", name)
+	}
+	roots := make([][]byte, length)
+	for i := range roots {
+		var err error
+		roots[i], err = root(i)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %d: %w", name, i, err)
+		}
+	}
+
+	return listRoot(roots, limit, uint64(length))
+}
+
+// listRoot returns the hash tree root of a list given its chunks, the maximum number of chunks and its length.
+func listRoot(chunks [][]byte, limit int, length uint64) ([]byte, error) {
+	padded := make([][]byte, limit)
+	copy(padded, chunks)
+	for i := len(chunks); i < limit; i++ {
+		padded[i] = make([]byte, 32)
+	}
+	root, err := util.Merkleize(padded)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.SHA256(root, uint64Root(length)), nil
+}
+
+// bitlistRoot returns the hash tree root of an SSZ-encoded bitlist with the given maximum number of bits.
+func bitlistRoot(data []byte, limit int) ([]byte, error) {
+	if len(data) == 0 || data[len(data)-1] == 0 {
+		return nil, fmt.Errorf("bitlist missing length bit")
+	}
+	// The highest set bit marks the length of the list, and is not part of its contents.
+	lengthBit := bits.Len8(data[len(data)-1]) - 1
+	length := (len(data)-1)*8 + lengthBit
+	if length > limit {
+		return nil, fmt.Errorf("bitlist has %d bits, more than %d", length, limit)
+	}
+	contents := make([]byte, (length+7)/8)
+	copy(contents, data)
+	if lengthBit != 0 {
+		contents[len(contents)-1] &^= 1 << lengthBit
+	}
+
+	return listRoot(util.Pack(contents), (limit+255)/256, uint64(length))
+}
+
+// bitvectorRoot returns the hash tree root of a bitvector with the given number of bits.
+func bitvectorRoot(data []byte, size int) ([]byte, error) {
+	if len(data) != (size+7)/8 {
+		return nil, fmt.Errorf("bitvector must be %d bytes", (size+7)/8)
+	}
+	chunks := util.Pack(data)
+	for len(chunks) < (size+255)/256 {
+		chunks = append(chunks, make([]byte, 32))
+	}
+
+	return util.Merkleize(chunks)
+}
@@ -0,0 +1,107 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web3signer
+
+import (
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+type parameters struct {
+	privateKeys           []*e2types.BLSPrivateKey
+	slashingProtection    util.SlashingProtection
+	genesisValidatorsRoot []byte
+	genesisForkVersion    []byte
+	slotsPerEpoch         uint64
+}
+
+// Parameter is the interface for service parameters.
+type Parameter interface {
+	apply(p *parameters)
+}
+
+type parameterFunc func(*parameters)
+
+func (f parameterFunc) apply(p *parameters) {
+	f(p)
+}
+
+// WithPrivateKeys sets the private keys with which the service signs.
+// Keys can be obtained with util.PrivateKeyFromSeedAndPath or util.PrivateKeyFromKeystore.
+func WithPrivateKeys(keys ...*e2types.BLSPrivateKey) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.privateKeys = append(p.privateKeys, keys...)
+	})
+}
+
+// WithSlashingProtection sets the slashing protection consulted before signing blocks and attestations.
+func WithSlashingProtection(protection util.SlashingProtection) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.slashingProtection = protection
+	})
+}
+
+// WithGenesisValidatorsRoot restricts the service to signing for the chain with the given genesis validators root.
+func WithGenesisValidatorsRoot(root []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.genesisValidatorsRoot = root
+	})
+}
+
+// WithGenesisForkVersion sets the genesis fork version of the chain, required to sign builder validator registrations.
+func WithGenesisForkVersion(version []byte) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.genesisForkVersion = version
+	})
+}
+
+// WithSlotsPerEpoch sets the number of slots per epoch, used to select the fork for slot-based requests.
+func WithSlotsPerEpoch(slotsPerEpoch uint64) Parameter {
+	return parameterFunc(func(p *parameters) {
+		p.slotsPerEpoch = slotsPerEpoch
+	})
+}
+
+// parseAndCheckParameters parses and checks parameters to ensure that mandatory parameters are present and correct.
+func parseAndCheckParameters(params ...Parameter) (*parameters, error) {
+	parameters := parameters{
+		slotsPerEpoch: 32,
+	}
+	for _, p := range params {
+		if p != nil {
+			p.apply(&parameters)
+		}
+	}
+
+	if parameters.slashingProtection == nil {
+		return nil, errors.New("no slashing protection specified")
+	}
+	for i := range parameters.privateKeys {
+		if parameters.privateKeys[i] == nil {
+			return nil, errors.New("nil private key specified")
+		}
+	}
+	if parameters.genesisValidatorsRoot != nil && len(parameters.genesisValidatorsRoot) != 32 {
+		return nil, errors.New("genesis validators root must be 32 bytes")
+	}
+	if parameters.genesisForkVersion != nil && len(parameters.genesisForkVersion) != 4 {
+		return nil, errors.New("genesis fork version must be 4 bytes")
+	}
+	if parameters.slotsPerEpoch == 0 {
+		return nil, errors.New("slots per epoch must be at least 1")
+	}
+
+	return &parameters, nil
+}
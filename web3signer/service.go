@@ -0,0 +1,149 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package web3signer provides an HTTP server that implements the Web3Signer Ethereum 2 signing API.
+package web3signer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+const (
+	upcheckPath    = "/upcheck"
+	publicKeysPath = "/api/v1/eth2/publicKeys"
+	signPathPrefix = "/api/v1/eth2/sign/"
+
+	// maxRequestSize is the maximum size of a signing request body.
+	maxRequestSize = 1024 * 1024
+)
+
+// Service is a signing service implementing the Web3Signer Ethereum 2 signing API.
+// It computes domains and signing roots from the request itself, and consults slashing protection
+// before signing blocks and attestations.
+type Service struct {
+	keys                  map[string]*e2types.BLSPrivateKey
+	pubKeys               []string
+	slashingProtection    util.SlashingProtection
+	genesisValidatorsRoot []byte
+	genesisForkVersion    []byte
+	slotsPerEpoch         uint64
+}
+
+// New creates a new signing service.
+func New(params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{
+		keys:                  make(map[string]*e2types.BLSPrivateKey, len(parameters.privateKeys)),
+		pubKeys:               make([]string, 0, len(parameters.privateKeys)),
+		slashingProtection:    parameters.slashingProtection,
+		genesisValidatorsRoot: parameters.genesisValidatorsRoot,
+		genesisForkVersion:    parameters.genesisForkVersion,
+		slotsPerEpoch:         parameters.slotsPerEpoch,
+	}
+	for _, key := range parameters.privateKeys {
+		pubKey := fmt.Sprintf("%#x", key.PublicKey().Marshal())
+		if _, exists := s.keys[pubKey]; exists {
+			continue
+		}
+		s.keys[pubKey] = key
+		s.pubKeys = append(s.pubKeys, pubKey)
+	}
+
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == upcheckPath:
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = io.WriteString(w, "OK")
+	case r.URL.Path == publicKeysPath:
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+			return
+		}
+		writeJSON(w, s.pubKeys)
+	case strings.HasPrefix(r.URL.Path, signPathPrefix):
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+			return
+		}
+		s.handleSign(w, r, strings.TrimPrefix(r.URL.Path, signPathPrefix))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleSign handles a signing request.
+func (s *Service) handleSign(w http.ResponseWriter, r *http.Request, pubKey string) {
+	key, exists := s.keys[strings.ToLower(pubKey)]
+	if !exists {
+		http.Error(w, "public key not found", http.StatusNotFound)
+
+		return
+	}
+
+	var req signRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestSize)).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+
+		return
+	}
+
+	sig, err := s.sign(key, &req)
+	if err != nil {
+		http.Error(w, err.Error(), err.status)
+
+		return
+	}
+
+	signature := fmt.Sprintf("%#x", sig.Marshal())
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		writeJSON(w, &signResponse{Signature: signature})
+
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = io.WriteString(w, signature)
+}
+
+// writeJSON writes a value as a JSON response.
+func writeJSON(w http.ResponseWriter, val any) {
+	data, err := json.Marshal(val)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,489 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web3signer_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+	"github.com/wealdtech/go-eth2-util/slashingprotection"
+	"github.com/wealdtech/go-eth2-util/web3signer"
+)
+
+func TestMain(m *testing.M) {
+	if err := e2types.InitBLS(); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+const (
+	testGVR      = "04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	testForkInfo = `"fork_info":{"fork":{"previous_version":"0x00000001","current_version":"0x00000002","epoch":"10"},` +
+		`"genesis_validators_root":"0x` + testGVR + `"}`
+)
+
+func testAttestationData(index uint64) string {
+	return fmt.Sprintf(`{"slot":"320","index":"%d","beacon_block_root":"0x%s","source":{"epoch":"8","root":"0x%s"},`+
+		`"target":{"epoch":"10","root":"0x%s"}}`, index, strings.Repeat("06", 32), strings.Repeat("04", 32), strings.Repeat("05", 32))
+}
+
+func testAttestation(index uint64, committeeBits string) string {
+	res := `{"aggregation_bits":"0x0b","data":` + testAttestationData(index) + `,"signature":"0x` + strings.Repeat("07", 96) + `"`
+	if committeeBits != "" {
+		res += `,"committee_bits":"0x` + committeeBits + `"`
+	}
+
+	return res + "}"
+}
+
+func testSignedHeader(slot uint64) string {
+	return fmt.Sprintf(`{"message":{"slot":"%d","proposer_index":"5","parent_root":"0x%s","state_root":"0x%s","body_root":"0x%s"},`+
+		`"signature":"0x%s"}`, slot, strings.Repeat("01", 32), strings.Repeat("02", 32), strings.Repeat("03", 32), strings.Repeat("07", 96))
+}
+
+func testIndexedAttestation(indices string) string {
+	return `{"attesting_indices":[` + indices + `],"data":` + testAttestationData(1) + `,"signature":"0x` + strings.Repeat("07", 96) + `"}`
+}
+
+func testPhase0Block() string {
+	proof := make([]string, 33)
+	for i := range proof {
+		proof[i] = `"0x` + strings.Repeat("0f", 32) + `"`
+	}
+
+	return `{"slot":"64","proposer_index":"5","parent_root":"0x` + strings.Repeat("01", 32) + `","state_root":"0x` +
+		strings.Repeat("02", 32) + `","body":{"randao_reveal":"0x` + strings.Repeat("0b", 96) + `",` +
+		`"eth1_data":{"deposit_root":"0x` + strings.Repeat("0c", 32) + `","deposit_count":"10","block_hash":"0x` + strings.Repeat("0d", 32) + `"},` +
+		`"graffiti":"0x` + strings.Repeat("0e", 32) + `",` +
+		`"proposer_slashings":[{"signed_header_1":` + testSignedHeader(64) + `,"signed_header_2":` + testSignedHeader(65) + `}],` +
+		`"attester_slashings":[{"attestation_1":` + testIndexedAttestation(`"1","2"`) + `,"attestation_2":` + testIndexedAttestation(`"2","3"`) + `}],` +
+		`"attestations":[` + testAttestation(1, "") + `],` +
+		`"deposits":[{"proof":[` + strings.Join(proof, ",") + `],"data":{"pubkey":"0x` + strings.Repeat("11", 48) +
+		`","withdrawal_credentials":"0x` + strings.Repeat("22", 32) + `","amount":"32000000000","signature":"0x` + strings.Repeat("33", 96) + `"}}],` +
+		`"voluntary_exits":[{"message":{"epoch":"12","validator_index":"5"},"signature":"0x` + strings.Repeat("07", 96) + `"}]}}`
+}
+
+func _byteArray(input string) []byte {
+	res, _ := hex.DecodeString(input)
+	return res
+}
+
+func testService(t *testing.T) (*web3signer.Service, *e2types.BLSPrivateKey) {
+	t.Helper()
+
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	key, err := util.PrivateKeyFromSeedAndPath(seed, "m/12381/3600/0/0/0")
+	require.NoError(t, err)
+	store, err := slashingprotection.NewStore(filepath.Join(t.TempDir(), "protection.json"), _byteArray(testGVR))
+	require.NoError(t, err)
+	service, err := web3signer.New(
+		web3signer.WithPrivateKeys(key, key),
+		web3signer.WithSlashingProtection(store),
+		web3signer.WithGenesisValidatorsRoot(_byteArray(testGVR)),
+		web3signer.WithGenesisForkVersion([]byte{0x00, 0x00, 0x10, 0x20}),
+	)
+	require.NoError(t, err)
+
+	return service, key
+}
+
+func request(service http.Handler, method string, path string, body string, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	service.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestNew(t *testing.T) {
+	_, err := web3signer.New()
+	require.EqualError(t, err, "no slashing protection specified")
+
+	store, err := slashingprotection.NewStore(filepath.Join(t.TempDir(), "protection.json"), _byteArray(testGVR))
+	require.NoError(t, err)
+	_, err = web3signer.New(web3signer.WithSlashingProtection(store), web3signer.WithPrivateKeys(nil))
+	require.EqualError(t, err, "nil private key specified")
+	_, err = web3signer.New(web3signer.WithSlashingProtection(store), web3signer.WithGenesisValidatorsRoot([]byte{0x01}))
+	require.EqualError(t, err, "genesis validators root must be 32 bytes")
+	_, err = web3signer.New(web3signer.WithSlashingProtection(store), web3signer.WithGenesisForkVersion([]byte{0x01}))
+	require.EqualError(t, err, "genesis fork version must be 4 bytes")
+	_, err = web3signer.New(web3signer.WithSlashingProtection(store), web3signer.WithSlotsPerEpoch(0))
+	require.EqualError(t, err, "slots per epoch must be at least 1")
+}
+
+func TestUpcheckAndPublicKeys(t *testing.T) {
+	service, key := testService(t)
+
+	rec := request(service, http.MethodGet, "/upcheck", "", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "OK", rec.Body.String())
+
+	rec = request(service, http.MethodGet, "/api/v1/eth2/publicKeys", "", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, fmt.Sprintf(`["%#x"]`, key.PublicKey().Marshal()), rec.Body.String())
+
+	rec = request(service, http.MethodPost, "/upcheck", "", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = request(service, http.MethodGet, "/unknown", "", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestSign(t *testing.T) {
+	service, key := testService(t)
+	signPath := fmt.Sprintf("/api/v1/eth2/sign/%#x", key.PublicKey().Marshal())
+
+	// Expected signatures are calculated independently with the library's signing helpers.
+	slotProof, err := util.SlotSelectionProof(key, 320, []byte{0x00, 0x00, 0x00, 0x02}, _byteArray(testGVR))
+	require.NoError(t, err)
+	earlySlotProof, err := util.SlotSelectionProof(key, 319, []byte{0x00, 0x00, 0x00, 0x01}, _byteArray(testGVR))
+	require.NoError(t, err)
+	syncProof, err := util.SyncCommitteeSelectionProof(key, 320, 2, []byte{0x00, 0x00, 0x00, 0x02}, _byteArray(testGVR))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		accept string
+		status int
+		res    string
+	}{
+		{
+			name:   "UnknownKey",
+			path:   "/api/v1/eth2/sign/0x" + strings.Repeat("00", 48),
+			body:   `{}`,
+			status: http.StatusNotFound,
+			res:    "public key not found\n",
+		},
+		{
+			name:   "InvalidJSON",
+			path:   signPath,
+			body:   `{`,
+			status: http.StatusBadRequest,
+			res:    "invalid request: unexpected EOF\n",
+		},
+		{
+			name:   "TypeMissing",
+			path:   signPath,
+			body:   `{}`,
+			status: http.StatusBadRequest,
+			res:    "type missing\n",
+		},
+		{
+			name:   "TypeUnsupported",
+			path:   signPath,
+			body:   `{"type":"UNKNOWN",` + testForkInfo + `}`,
+			status: http.StatusBadRequest,
+			res:    "type UNKNOWN not supported\n",
+		},
+		{
+			name:   "ForkInfoMissing",
+			path:   signPath,
+			body:   `{"type":"AGGREGATION_SLOT","aggregation_slot":{"slot":"320"}}`,
+			status: http.StatusBadRequest,
+			res:    "fork info missing\n",
+		},
+		{
+			name: "GenesisValidatorsRootUnsupported",
+			path: signPath,
+			body: `{"type":"AGGREGATION_SLOT","aggregation_slot":{"slot":"320"},` +
+				`"fork_info":{"fork":{"previous_version":"0x00000001","current_version":"0x00000002","epoch":"10"},` +
+				`"genesis_validators_root":"0x` + strings.Repeat("00", 32) + `"}}`,
+			status: http.StatusBadRequest,
+			res:    "genesis validators root 0x" + strings.Repeat("00", 32) + " not supported\n",
+		},
+		{
+			name:   "AggregateAndProofMissing",
+			path:   signPath,
+			body:   `{"type":"AGGREGATE_AND_PROOF",` + testForkInfo + `}`,
+			status: http.StatusBadRequest,
+			res:    "aggregate and proof missing\n",
+		},
+		{
+			name:   "AggregateAndProofVersionMissing",
+			path:   signPath,
+			body:   `{"type":"AGGREGATE_AND_PROOF_V2","aggregate_and_proof":{"data":{}},` + testForkInfo + `}`,
+			status: http.StatusBadRequest,
+			res:    "invalid aggregate and proof: version missing\n",
+		},
+		{
+			name: "AggregateAndProofBitlistTooLong",
+			path: signPath,
+			body: `{"type":"AGGREGATE_AND_PROOF","aggregate_and_proof":{"aggregator_index":"7","aggregate":{"aggregation_bits":"0x` +
+				strings.Repeat("ff", 256) + `03","data":` + testAttestationData(1) + `,"signature":"0x` + strings.Repeat("07", 96) +
+				`"},"selection_proof":"0x` + strings.Repeat("08", 96) + `"},` + testForkInfo + `}`,
+			status: http.StatusBadRequest,
+			res:    "invalid aggregate and proof: invalid aggregate: invalid aggregation bits: bitlist has 2049 bits, more than 2048\n",
+		},
+		{
+			name:   "BlockBodyMissing",
+			path:   signPath,
+			body:   `{"type":"BLOCK","block":{"slot":"64"},` + testForkInfo + `}`,
+			status: http.StatusBadRequest,
+			res:    "invalid block: body missing\n",
+		},
+		{
+			name: "ValidatorRegistrationInvalid",
+			path: signPath,
+			body: `{"type":"VALIDATOR_REGISTRATION","validator_registration":{"fee_recipient":"0x12","gas_limit":"30000000",` +
+				`"timestamp":"1700000000","pubkey":"0x` + strings.Repeat("11", 48) + `"}}`,
+			status: http.StatusBadRequest,
+			res:    "invalid validator registration: fee recipient must be 20 bytes\n",
+		},
+		{
+			name:   "AggregationSlot",
+			path:   signPath,
+			body:   `{"type":"AGGREGATION_SLOT","aggregation_slot":{"slot":"320"},` + testForkInfo + `}`,
+			status: http.StatusOK,
+			res:    fmt.Sprintf("%#x", slotProof.Marshal()),
+		},
+		{
+			name:   "AggregationSlotPreviousFork",
+			path:   signPath,
+			body:   `{"type":"AGGREGATION_SLOT","aggregation_slot":{"slot":"319"},` + testForkInfo + `}`,
+			status: http.StatusOK,
+			res:    fmt.Sprintf("%#x", earlySlotProof.Marshal()),
+		},
+		{
+			name:   "AggregationSlotJSON",
+			path:   signPath,
+			body:   `{"type":"AGGREGATION_SLOT","aggregation_slot":{"slot":"320"},` + testForkInfo + `}`,
+			accept: "application/json",
+			status: http.StatusOK,
+			res:    fmt.Sprintf(`{"signature":"%#x"}`, slotProof.Marshal()),
+		},
+		{
+			name: "SigningRootMismatch",
+			path: signPath,
+			body: `{"type":"AGGREGATION_SLOT","aggregation_slot":{"slot":"320"},` + testForkInfo +
+				`,"signingRoot":"0x` + strings.Repeat("00", 32) + `"}`,
+			status: http.StatusBadRequest,
+			res:    "signing root 0x" + strings.Repeat("00", 32) + " does not match computed signing root",
+		},
+		{
+			name:   "SyncCommitteeSelectionProof",
+			path:   signPath,
+			body:   `{"type":"SYNC_COMMITTEE_SELECTION_PROOF","sync_aggregator_selection_data":{"slot":"320","subcommittee_index":"2"},` + testForkInfo + `}`,
+			status: http.StatusOK,
+			res:    fmt.Sprintf("%#x", syncProof.Marshal()),
+		},
+		{
+			name:   "RandaoRevealMissing",
+			path:   signPath,
+			body:   `{"type":"RANDAO_REVEAL",` + testForkInfo + `}`,
+			status: http.StatusBadRequest,
+			res:    "randao reveal missing\n",
+		},
+		{
+			name:   "BlockFull",
+			path:   signPath,
+			body:   `{"type":"BLOCK_V2","beacon_block":{"version":"PHASE0","block":{}},` + testForkInfo + `}`,
+			status: http.StatusBadRequest,
+			res:    "block header missing; only block headers are supported\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := request(service, http.MethodPost, test.path, test.body, test.accept)
+			assert.Equal(t, test.status, rec.Code)
+			assert.True(t, strings.HasPrefix(rec.Body.String(), test.res), rec.Body.String())
+		})
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	service, key := testService(t)
+	signPath := fmt.Sprintf("/api/v1/eth2/sign/%#x", key.PublicKey().Marshal())
+	currentVersion := []byte{0x00, 0x00, 0x00, 0x02}
+
+	tests := []struct {
+		name        string
+		body        string
+		domainType  e2types.DomainType
+		forkVersion []byte
+		gvr         []byte
+		objectRoot  []byte
+	}{
+		{
+			name:        "RandaoReveal",
+			body:        `{"type":"RANDAO_REVEAL","randao_reveal":{"epoch":"12"},` + testForkInfo + `}`,
+			domainType:  e2types.DomainRANDAO,
+			forkVersion: currentVersion,
+			gvr:         _byteArray(testGVR),
+			objectRoot:  _byteArray("0c00000000000000000000000000000000000000000000000000000000000000"),
+		},
+		{
+			name:        "VoluntaryExit",
+			body:        `{"type":"VOLUNTARY_EXIT","voluntary_exit":{"epoch":"12","validator_index":"5"},` + testForkInfo + `}`,
+			domainType:  e2types.DomainVoluntaryExit,
+			forkVersion: currentVersion,
+			gvr:         _byteArray(testGVR),
+			objectRoot: util.SHA256(_byteArray("0c00000000000000000000000000000000000000000000000000000000000000"),
+				_byteArray("0500000000000000000000000000000000000000000000000000000000000000")),
+		},
+		{
+			name: "SyncCommitteeMessage",
+			body: `{"type":"SYNC_COMMITTEE_MESSAGE","sync_committee_message":{"beacon_block_root":"0x` + strings.Repeat("aa", 32) +
+				`","slot":"320"},` + testForkInfo + `}`,
+			domainType:  e2types.DomainSyncCommittee,
+			forkVersion: currentVersion,
+			gvr:         _byteArray(testGVR),
+			objectRoot:  bytes.Repeat([]byte{0xaa}, 32),
+		},
+		{
+			name: "Deposit",
+			body: `{"type":"DEPOSIT","deposit":{"pubkey":"0x` + strings.Repeat("11", 48) + `","withdrawal_credentials":"0x` +
+				strings.Repeat("22", 32) + `","amount":"32000000000","genesis_fork_version":"0x00001020"}}`,
+			domainType:  e2types.DomainDeposit,
+			forkVersion: []byte{0x00, 0x00, 0x10, 0x20},
+			gvr:         e2types.ZeroGenesisValidatorsRoot,
+			objectRoot: util.SHA256(
+				util.SHA256(util.SHA256(bytes.Repeat([]byte{0x11}, 32), append(bytes.Repeat([]byte{0x11}, 16), make([]byte, 16)...)),
+					bytes.Repeat([]byte{0x22}, 32)),
+				util.SHA256(_byteArray("0040597307000000000000000000000000000000000000000000000000000000"), make([]byte, 32)),
+			),
+		},
+		// The object roots below were calculated independently with a separate SSZ implementation.
+		{
+			name: "AggregateAndProof",
+			body: `{"type":"AGGREGATE_AND_PROOF","aggregate_and_proof":{"aggregator_index":"7","aggregate":` + testAttestation(1, "") +
+				`,"selection_proof":"0x` + strings.Repeat("08", 96) + `"},` + testForkInfo + `}`,
+			domainType:  e2types.DomainAggregateAndProof,
+			forkVersion: currentVersion,
+			gvr:         _byteArray(testGVR),
+			objectRoot:  _byteArray("427b5d4702f326588b25c9b0b28edd0e02028d85c7ce0ff4e60b45bd3be795bd"),
+		},
+		{
+			name: "AggregateAndProofV2Phase0",
+			body: `{"type":"AGGREGATE_AND_PROOF_V2","aggregate_and_proof":{"version":"PHASE0","data":{"aggregator_index":"7",` +
+				`"aggregate":` + testAttestation(1, "") + `,"selection_proof":"0x` + strings.Repeat("08", 96) + `"}},` + testForkInfo + `}`,
+			domainType:  e2types.DomainAggregateAndProof,
+			forkVersion: currentVersion,
+			gvr:         _byteArray(testGVR),
+			objectRoot:  _byteArray("427b5d4702f326588b25c9b0b28edd0e02028d85c7ce0ff4e60b45bd3be795bd"),
+		},
+		{
+			name: "AggregateAndProofV2Electra",
+			body: `{"type":"AGGREGATE_AND_PROOF_V2","aggregate_and_proof":{"version":"ELECTRA","data":{"aggregator_index":"7",` +
+				`"aggregate":` + testAttestation(0, "0200000000000000") + `,"selection_proof":"0x` + strings.Repeat("08", 96) + `"}},` +
+				testForkInfo + `}`,
+			domainType:  e2types.DomainAggregateAndProof,
+			forkVersion: currentVersion,
+			gvr:         _byteArray(testGVR),
+			objectRoot:  _byteArray("dfea23464d7869d3be8fa86f34799546d9ad61f5847595f64b29eb7e95f306f1"),
+		},
+		{
+			name: "SyncCommitteeContributionAndProof",
+			body: `{"type":"SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF","contribution_and_proof":{"aggregator_index":"3",` +
+				`"contribution":{"slot":"320","beacon_block_root":"0x` + strings.Repeat("aa", 32) + `","subcommittee_index":"1",` +
+				`"aggregation_bits":"0xff` + strings.Repeat("00", 15) + `","signature":"0x` + strings.Repeat("09", 96) + `"},` +
+				`"selection_proof":"0x` + strings.Repeat("0a", 96) + `"},` + testForkInfo + `}`,
+			domainType:  e2types.DomainContributionAndProof,
+			forkVersion: currentVersion,
+			gvr:         _byteArray(testGVR),
+			objectRoot:  _byteArray("8c6fa5798164b8833b770d5448d274f62dc2d141670e8c26f566de7d2974bf95"),
+		},
+		{
+			name: "ValidatorRegistration",
+			body: `{"type":"VALIDATOR_REGISTRATION","validator_registration":{"fee_recipient":"0x` + strings.Repeat("12", 20) +
+				`","gas_limit":"30000000","timestamp":"1700000000","pubkey":"0x` + strings.Repeat("11", 48) + `"}}`,
+			domainType:  e2types.DomainType{0x00, 0x00, 0x00, 0x01},
+			forkVersion: []byte{0x00, 0x00, 0x10, 0x20},
+			gvr:         e2types.ZeroGenesisValidatorsRoot,
+			objectRoot:  _byteArray("ba5ae8bb5e5127cec51e32de6b0d9861db3379fd1c708b79e20f4434fbf71beb"),
+		},
+		{
+			name:        "BlockPhase0",
+			body:        `{"type":"BLOCK","block":` + testPhase0Block() + `,` + testForkInfo + `}`,
+			domainType:  e2types.DomainBeaconProposer,
+			forkVersion: []byte{0x00, 0x00, 0x00, 0x01},
+			gvr:         _byteArray(testGVR),
+			objectRoot:  _byteArray("8a84fc718bbb62a71fde5d22ac703a9678d408599c77051efdc948aed02d1da8"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := request(service, http.MethodPost, signPath, test.body, "")
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+			domain, err := e2types.ComputeDomain(test.domainType, test.forkVersion, test.gvr)
+			require.NoError(t, err)
+			signingRoot, err := util.ComputeSigningRoot(test.objectRoot, domain)
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("%#x", key.Sign(signingRoot).Marshal()), rec.Body.String())
+		})
+	}
+}
+
+func TestSignSlashingProtection(t *testing.T) {
+	service, key := testService(t)
+	signPath := fmt.Sprintf("/api/v1/eth2/sign/%#x", key.PublicKey().Marshal())
+
+	block := func(stateRoot string) string {
+		return `{"type":"BLOCK_V2","beacon_block":{"version":"DENEB","block_header":{"slot":"320","proposer_index":"5",` +
+			`"parent_root":"0x` + strings.Repeat("01", 32) + `","state_root":"0x` + stateRoot + `","body_root":"0x` +
+			strings.Repeat("03", 32) + `"}},` + testForkInfo + `}`
+	}
+	rec := request(service, http.MethodPost, signPath, block(strings.Repeat("02", 32)), "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	header := &util.BeaconBlockHeader{
+		Slot:          320,
+		ProposerIndex: 5,
+		ParentRoot:    bytes.Repeat([]byte{0x01}, 32),
+		StateRoot:     bytes.Repeat([]byte{0x02}, 32),
+		BodyRoot:      bytes.Repeat([]byte{0x03}, 32),
+	}
+	store, err := slashingprotection.NewStore(filepath.Join(t.TempDir(), "protection.json"), _byteArray(testGVR))
+	require.NoError(t, err)
+	expected, err := util.SignBeaconBlockHeader(key, store, header, []byte{0x00, 0x00, 0x00, 0x02}, _byteArray(testGVR))
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%#x", expected.Marshal()), rec.Body.String())
+
+	// Repeating the same block is allowed, but a different block at the same slot is not.
+	rec = request(service, http.MethodPost, signPath, block(strings.Repeat("02", 32)), "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = request(service, http.MethodPost, signPath, block(strings.Repeat("04", 32)), "")
+	require.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.Equal(t, "slashable: double proposal at slot 320\n", rec.Body.String())
+
+	attestation := func(source uint64, target uint64) string {
+		return fmt.Sprintf(`{"type":"ATTESTATION","attestation":{"slot":"%d","index":"0","beacon_block_root":"0x%s",`+
+			`"source":{"epoch":"%d","root":"0x%s"},"target":{"epoch":"%d","root":"0x%s"}},%s}`,
+			target*32, strings.Repeat("06", 32), source, strings.Repeat("04", 32), target, strings.Repeat("05", 32), testForkInfo)
+	}
+	rec = request(service, http.MethodPost, signPath, attestation(8, 12), "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = request(service, http.MethodPost, signPath, attestation(9, 11), "")
+	require.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.Equal(t, "slashable: 9->11 is surrounded by 8->12\n", rec.Body.String())
+}
@@ -0,0 +1,456 @@
+// Copyright © 2026 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web3signer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
+)
+
+// hexBytes is a hex string, optionally 0x-prefixed.
+type hexBytes []byte
+
+func (h *hexBytes) UnmarshalJSON(input []byte) error {
+	var str string
+	if err := json.Unmarshal(input, &str); err != nil {
+		return err
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		return err
+	}
+	*h = data
+
+	return nil
+}
+
+type signRequest struct {
+	Type                        string                       `json:"type"`
+	ForkInfo                    *forkInfoJSON                `json:"fork_info"`
+	SigningRoot                 hexBytes                     `json:"signingRoot"` //nolint:tagliatelle
+	AggregationSlot             *aggregationSlotJSON         `json:"aggregation_slot"`
+	RandaoReveal                *randaoRevealJSON            `json:"randao_reveal"`
+	VoluntaryExit               *voluntaryExitJSON           `json:"voluntary_exit"`
+	SyncCommitteeMessage        *syncCommitteeMessageJSON    `json:"sync_committee_message"`
+	SyncAggregatorSelectionData *syncAggregatorSelectionJSON `json:"sync_aggregator_selection_data"`
+	Attestation                 *attestationDataJSON         `json:"attestation"`
+	BeaconBlock                 *beaconBlockJSON             `json:"beacon_block"`
+	Deposit                     *depositJSON                 `json:"deposit"`
+	AggregateAndProof           json.RawMessage              `json:"aggregate_and_proof"`
+	ContributionAndProof        *contributionAndProofJSON    `json:"contribution_and_proof"`
+	ValidatorRegistration       *validatorRegistrationJSON   `json:"validator_registration"`
+	Block                       *phase0BeaconBlockJSON       `json:"block"`
+}
+
+type forkInfoJSON struct {
+	Fork                  *forkJSON `json:"fork"`
+	GenesisValidatorsRoot hexBytes  `json:"genesis_validators_root"`
+}
+
+type forkJSON struct {
+	PreviousVersion hexBytes `json:"previous_version"`
+	CurrentVersion  hexBytes `json:"current_version"`
+	Epoch           uint64   `json:"epoch,string"`
+}
+
+type aggregationSlotJSON struct {
+	Slot uint64 `json:"slot,string"`
+}
+
+type randaoRevealJSON struct {
+	Epoch uint64 `json:"epoch,string"`
+}
+
+type voluntaryExitJSON struct {
+	Epoch          uint64 `json:"epoch,string"`
+	ValidatorIndex uint64 `json:"validator_index,string"`
+}
+
+type syncCommitteeMessageJSON struct {
+	BeaconBlockRoot hexBytes `json:"beacon_block_root"`
+	Slot            uint64   `json:"slot,string"`
+}
+
+type syncAggregatorSelectionJSON struct {
+	Slot              uint64 `json:"slot,string"`
+	SubcommitteeIndex uint64 `json:"subcommittee_index,string"`
+}
+
+type checkpointJSON struct {
+	Epoch uint64   `json:"epoch,string"`
+	Root  hexBytes `json:"root"`
+}
+
+type attestationDataJSON struct {
+	Slot            uint64          `json:"slot,string"`
+	Index           uint64          `json:"index,string"`
+	BeaconBlockRoot hexBytes        `json:"beacon_block_root"`
+	Source          *checkpointJSON `json:"source"`
+	Target          *checkpointJSON `json:"target"`
+}
+
+type beaconBlockJSON struct {
+	Version     string                 `json:"version"`
+	BlockHeader *beaconBlockHeaderJSON `json:"block_header"`
+}
+
+type beaconBlockHeaderJSON struct {
+	Slot          uint64   `json:"slot,string"`
+	ProposerIndex uint64   `json:"proposer_index,string"`
+	ParentRoot    hexBytes `json:"parent_root"`
+	StateRoot     hexBytes `json:"state_root"`
+	BodyRoot      hexBytes `json:"body_root"`
+}
+
+type depositJSON struct {
+	PublicKey             hexBytes `json:"pubkey"`
+	WithdrawalCredentials hexBytes `json:"withdrawal_credentials"`
+	Amount                uint64   `json:"amount,string"`
+	GenesisForkVersion    hexBytes `json:"genesis_fork_version"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+// signError is an error with the HTTP status to return for it.
+type signError struct {
+	status int
+	msg    string
+}
+
+func (e *signError) Error() string {
+	return e.msg
+}
+
+func badRequest(format string, args ...any) *signError {
+	return &signError{status: http.StatusBadRequest, msg: fmt.Sprintf(format, args...)}
+}
+
+// signable is the information required to sign a request.
+type signable struct {
+	domainType e2types.DomainType
+	// epoch is the epoch used to select the fork version.
+	epoch      uint64
+	objectRoot []byte
+	// protect checks the signing root with slashing protection, if required.
+	protect func(pubKey []byte, signingRoot []byte) error
+}
+
+// sign signs a request.
+func (s *Service) sign(key *e2types.BLSPrivateKey, req *signRequest) (e2types.Signature, *signError) {
+	item, err := s.signable(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var forkVersion []byte
+	var genesisValidatorsRoot []byte
+	switch req.Type {
+	case "DEPOSIT":
+		// Deposits are signed with the genesis fork version and no genesis validators root, so are valid on any fork.
+		forkVersion = req.Deposit.GenesisForkVersion
+		genesisValidatorsRoot = e2types.ZeroGenesisValidatorsRoot
+	case "VALIDATOR_REGISTRATION":
+		// Builder registrations are likewise signed with the genesis fork version and no genesis validators root.
+		forkVersion = s.genesisForkVersion
+		genesisValidatorsRoot = e2types.ZeroGenesisValidatorsRoot
+	default:
+		if req.ForkInfo == nil || req.ForkInfo.Fork == nil {
+			return nil, badRequest("fork info missing")
+		}
+		genesisValidatorsRoot = req.ForkInfo.GenesisValidatorsRoot
+		if s.genesisValidatorsRoot != nil && !bytes.Equal(genesisValidatorsRoot, s.genesisValidatorsRoot) {
+			return nil, badRequest("genesis validators root %#x not supported", genesisValidatorsRoot)
+		}
+		forkVersion = req.ForkInfo.Fork.CurrentVersion
+		if item.epoch < req.ForkInfo.Fork.Epoch {
+			forkVersion = req.ForkInfo.Fork.PreviousVersion
+		}
+	}
+
+	domain, domainErr := e2types.ComputeDomain(item.domainType, forkVersion, genesisValidatorsRoot)
+	if domainErr != nil {
+		return nil, badRequest("failed to compute domain: %v", domainErr)
+	}
+	signingRoot, rootErr := util.ComputeSigningRoot(item.objectRoot, domain)
+	if rootErr != nil {
+		return nil, badRequest("failed to compute signing root: %v", rootErr)
+	}
+	if req.SigningRoot != nil && !bytes.Equal(req.SigningRoot, signingRoot) {
+		return nil, badRequest("signing root %#x does not match computed signing root %#x", []byte(req.SigningRoot), signingRoot)
+	}
+
+	if item.protect != nil {
+		if err := item.protect(key.PublicKey().Marshal(), signingRoot); err != nil {
+			return nil, &signError{status: http.StatusPreconditionFailed, msg: err.Error()}
+		}
+	}
+
+	return key.Sign(signingRoot), nil
+}
+
+// signable obtains the information required to sign a request.
+func (s *Service) signable(req *signRequest) (*signable, *signError) {
+	switch req.Type {
+	case "AGGREGATION_SLOT":
+		if req.AggregationSlot == nil {
+			return nil, badRequest("aggregation slot missing")
+		}
+
+		return &signable{
+			domainType: e2types.DomainSelectionProof,
+			epoch:      req.AggregationSlot.Slot / s.slotsPerEpoch,
+			objectRoot: uint64Root(req.AggregationSlot.Slot),
+		}, nil
+	case "RANDAO_REVEAL":
+		if req.RandaoReveal == nil {
+			return nil, badRequest("randao reveal missing")
+		}
+
+		return &signable{
+			domainType: e2types.DomainRANDAO,
+			epoch:      req.RandaoReveal.Epoch,
+			objectRoot: uint64Root(req.RandaoReveal.Epoch),
+		}, nil
+	case "VOLUNTARY_EXIT":
+		if req.VoluntaryExit == nil {
+			return nil, badRequest("voluntary exit missing")
+		}
+
+		return &signable{
+			domainType: e2types.DomainVoluntaryExit,
+			epoch:      req.VoluntaryExit.Epoch,
+			objectRoot: util.SHA256(uint64Root(req.VoluntaryExit.Epoch), uint64Root(req.VoluntaryExit.ValidatorIndex)),
+		}, nil
+	case "SYNC_COMMITTEE_MESSAGE":
+		if req.SyncCommitteeMessage == nil {
+			return nil, badRequest("sync committee message missing")
+		}
+
+		return &signable{
+			domainType: e2types.DomainSyncCommittee,
+			epoch:      req.SyncCommitteeMessage.Slot / s.slotsPerEpoch,
+			objectRoot: req.SyncCommitteeMessage.BeaconBlockRoot,
+		}, nil
+	case "SYNC_COMMITTEE_SELECTION_PROOF":
+		if req.SyncAggregatorSelectionData == nil {
+			return nil, badRequest("sync aggregator selection data missing")
+		}
+		data := req.SyncAggregatorSelectionData
+
+		return &signable{
+			domainType: e2types.DomainSyncCommitteeSelectionProof,
+			epoch:      data.Slot / s.slotsPerEpoch,
+			objectRoot: util.SHA256(uint64Root(data.Slot), uint64Root(data.SubcommitteeIndex)),
+		}, nil
+	case "ATTESTATION":
+		return s.attestationSignable(req.Attestation)
+	case "AGGREGATE_AND_PROOF", "AGGREGATE_AND_PROOF_V2":
+		return s.aggregateAndProofSignable(req.AggregateAndProof, req.Type == "AGGREGATE_AND_PROOF_V2")
+	case "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF":
+		return s.contributionAndProofSignable(req.ContributionAndProof)
+	case "BLOCK":
+		return s.phase0BlockSignable(req.Block)
+	case "BLOCK_V2":
+		return s.blockSignable(req.BeaconBlock)
+	case "DEPOSIT":
+		return depositSignable(req.Deposit)
+	case "VALIDATOR_REGISTRATION":
+		return s.validatorRegistrationSignable(req.ValidatorRegistration)
+	case "":
+		return nil, badRequest("type missing")
+	default:
+		return nil, badRequest("type %s not supported", req.Type)
+	}
+}
+
+// attestationSignable obtains the information required to sign attestation data.
+func (s *Service) attestationSignable(data *attestationDataJSON) (*signable, *signError) {
+	if data == nil {
+		return nil, badRequest("attestation missing")
+	}
+	if data.Source == nil || data.Target == nil {
+		return nil, badRequest("attestation checkpoints missing")
+	}
+	attestationData := &util.AttestationData{
+		Slot:            data.Slot,
+		Index:           data.Index,
+		BeaconBlockRoot: data.BeaconBlockRoot,
+		Source:          &util.Checkpoint{Epoch: data.Source.Epoch, Root: data.Source.Root},
+		Target:          &util.Checkpoint{Epoch: data.Target.Epoch, Root: data.Target.Root},
+	}
+	root, err := attestationData.HashTreeRoot()
+	if err != nil {
+		return nil, badRequest("invalid attestation: %v", err)
+	}
+
+	return &signable{
+		domainType: e2types.DomainBeaconAttester,
+		epoch:      data.Target.Epoch,
+		objectRoot: root,
+		protect: func(pubKey []byte, signingRoot []byte) error {
+			return s.slashingProtection.CheckAndRecordAttestation(pubKey, data.Source.Epoch, data.Target.Epoch, signingRoot)
+		},
+	}, nil
+}
+
+// aggregateAndProofSignable obtains the information required to sign an aggregate and proof.
+func (s *Service) aggregateAndProofSignable(input json.RawMessage, versioned bool) (*signable, *signError) {
+	aggregateAndProof, electra, err := decodeAggregateAndProof(input, versioned)
+	if err != nil {
+		return nil, badRequest("invalid aggregate and proof: %v", err)
+	}
+	if aggregateAndProof == nil {
+		return nil, badRequest("aggregate and proof missing")
+	}
+	root, err := aggregateAndProof.hashTreeRoot(electra)
+	if err != nil {
+		return nil, badRequest("invalid aggregate and proof: %v", err)
+	}
+
+	return &signable{
+		domainType: e2types.DomainAggregateAndProof,
+		epoch:      aggregateAndProof.Aggregate.Data.Slot / s.slotsPerEpoch,
+		objectRoot: root,
+	}, nil
+}
+
+// contributionAndProofSignable obtains the information required to sign a sync committee contribution and proof.
+func (s *Service) contributionAndProofSignable(contributionAndProof *contributionAndProofJSON) (*signable, *signError) {
+	if contributionAndProof == nil {
+		return nil, badRequest("contribution and proof missing")
+	}
+	root, err := contributionAndProof.hashTreeRoot()
+	if err != nil {
+		return nil, badRequest("invalid contribution and proof: %v", err)
+	}
+
+	return &signable{
+		domainType: e2types.DomainContributionAndProof,
+		epoch:      contributionAndProof.Contribution.Slot / s.slotsPerEpoch,
+		objectRoot: root,
+	}, nil
+}
+
+// phase0BlockSignable obtains the information required to sign a full phase 0 block.
+func (s *Service) phase0BlockSignable(block *phase0BeaconBlockJSON) (*signable, *signError) {
+	if block == nil {
+		return nil, badRequest("block missing")
+	}
+	header, err := block.header()
+	if err != nil {
+		return nil, badRequest("invalid block: %v", err)
+	}
+
+	return s.headerSignable(header)
+}
+
+// blockSignable obtains the information required to sign a block.
+func (s *Service) blockSignable(block *beaconBlockJSON) (*signable, *signError) {
+	if block == nil {
+		return nil, badRequest("beacon block missing")
+	}
+	// Full blocks are only provided for early forks; all clients can send headers, from which the block root is obtained.
+	if block.BlockHeader == nil {
+		return nil, badRequest("block header missing; only block headers are supported")
+	}
+
+	return s.headerSignable(&util.BeaconBlockHeader{
+		Slot:          block.BlockHeader.Slot,
+		ProposerIndex: block.BlockHeader.ProposerIndex,
+		ParentRoot:    block.BlockHeader.ParentRoot,
+		StateRoot:     block.BlockHeader.StateRoot,
+		BodyRoot:      block.BlockHeader.BodyRoot,
+	})
+}
+
+// headerSignable obtains the information required to sign a block given its header.
+func (s *Service) headerSignable(header *util.BeaconBlockHeader) (*signable, *signError) {
+	root, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, badRequest("invalid block header: %v", err)
+	}
+
+	return &signable{
+		domainType: e2types.DomainBeaconProposer,
+		epoch:      header.Slot / s.slotsPerEpoch,
+		objectRoot: root,
+		protect: func(pubKey []byte, signingRoot []byte) error {
+			return s.slashingProtection.CheckAndRecordBlock(pubKey, header.Slot, signingRoot)
+		},
+	}, nil
+}
+
+// depositSignable obtains the information required to sign a deposit.
+func depositSignable(deposit *depositJSON) (*signable, *signError) {
+	if deposit == nil {
+		return nil, badRequest("deposit missing")
+	}
+	if len(deposit.PublicKey) != 48 {
+		return nil, badRequest("deposit public key must be 48 bytes")
+	}
+	if len(deposit.WithdrawalCredentials) != 32 {
+		return nil, badRequest("deposit withdrawal credentials must be 32 bytes")
+	}
+	message := &util.DepositMessage{
+		PublicKey:             deposit.PublicKey,
+		WithdrawalCredentials: deposit.WithdrawalCredentials,
+		Amount:                util.Gwei(deposit.Amount),
+	}
+	root, err := message.HashTreeRoot()
+	if err != nil {
+		return nil, badRequest("invalid deposit: %v", err)
+	}
+
+	return &signable{
+		domainType: e2types.DomainDeposit,
+		objectRoot: root,
+	}, nil
+}
+
+// validatorRegistrationSignable obtains the information required to sign a builder validator registration.
+func (s *Service) validatorRegistrationSignable(registration *validatorRegistrationJSON) (*signable, *signError) {
+	if registration == nil {
+		return nil, badRequest("validator registration missing")
+	}
+	if s.genesisForkVersion == nil {
+		return nil, badRequest("genesis fork version not configured; cannot sign validator registrations")
+	}
+	root, err := registration.hashTreeRoot()
+	if err != nil {
+		return nil, badRequest("invalid validator registration: %v", err)
+	}
+
+	return &signable{
+		domainType: util.DomainApplicationBuilder,
+		objectRoot: root,
+	}, nil
+}
+
+// uint64Root returns the hash tree root of a uint64.
+func uint64Root(val uint64) []byte {
+	res := make([]byte, 32)
+	binary.LittleEndian.PutUint64(res, val)
+
+	return res
+}